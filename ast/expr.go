@@ -0,0 +1,233 @@
+/*
+Package ast holds the syntax tree built by the parser. Expressions and statements are walked with the
+visitor pattern, and embed the span of source code they were parsed from.
+*/
+package ast
+
+import "github.com/idea456/psu-lang/scanner"
+
+type VisitorExpr interface {
+	VisitLiteralExpr(*Literal) interface{}
+	VisitUnaryExpr(*Unary) interface{}
+	VisitBinaryExpr(*Binary) interface{}
+	VisitVariableExpr(*Variable) interface{}
+	VisitGroupExpr(*Group) interface{}
+	VisitLogicalExpr(*Logical) interface{}
+	VisitCallExpr(*Call) interface{}
+	VisitGetExpr(*Get) interface{}
+	VisitSetExpr(*Set) interface{}
+	VisitThisExpr(*This) interface{}
+	VisitParentExpr(*Parent) interface{}
+	VisitListLiteralExpr(*ListLiteral) interface{}
+	VisitIndexExpr(*Index) interface{}
+	VisitSetIndexExpr(*SetIndex) interface{}
+	VisitLengthExpr(*Length) interface{}
+	VisitMapLiteralExpr(*MapLiteral) interface{}
+	VisitKeysExpr(*Keys) interface{}
+	VisitRangeExpr(*RangeExpr) interface{}
+	VisitInterpolationExpr(*Interpolation) interface{}
+}
+
+type Expression interface {
+	Accept(VisitorExpr) interface{}
+	Location() scanner.Span
+}
+
+type Literal struct {
+	scanner.Span
+	Value interface{}
+}
+
+func (expr *Literal) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitLiteralExpr(expr)
+}
+
+type Unary struct {
+	scanner.Span
+	Operator scanner.Token
+	Right    Expression
+}
+
+func (expr *Unary) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitUnaryExpr(expr)
+}
+
+type Binary struct {
+	scanner.Span
+	Left     Expression
+	Operator scanner.Token
+	Right    Expression
+}
+
+func (expr *Binary) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitBinaryExpr(expr)
+}
+
+type Variable struct {
+	scanner.Span
+	Name scanner.Token
+}
+
+func (expr *Variable) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitVariableExpr(expr)
+}
+
+type Group struct {
+	scanner.Span
+	Expression Expression
+}
+
+func (expr *Group) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitGroupExpr(expr)
+}
+
+type Logical struct {
+	scanner.Span
+	Left     Expression
+	Operator scanner.Token
+	Right    Expression
+}
+
+func (expr *Logical) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitLogicalExpr(expr)
+}
+
+type Call struct {
+	scanner.Span
+	Callee    Expression
+	Paren     scanner.Token
+	Arguments []Expression
+}
+
+func (expr *Call) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitCallExpr(expr)
+}
+
+type Get struct {
+	scanner.Span
+	Object Expression
+	Name   scanner.Token
+}
+
+func (expr *Get) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitGetExpr(expr)
+}
+
+type Set struct {
+	scanner.Span
+	Object Expression
+	Name   scanner.Token
+	Value  Expression
+}
+
+func (expr *Set) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitSetExpr(expr)
+}
+
+type This struct {
+	scanner.Span
+	Keyword scanner.Token
+}
+
+func (expr *This) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitThisExpr(expr)
+}
+
+type Parent struct {
+	scanner.Span
+	Keyword scanner.Token
+	Method  scanner.Token
+}
+
+func (expr *Parent) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitParentExpr(expr)
+}
+
+type ListLiteral struct {
+	scanner.Span
+	Elements []Expression
+}
+
+func (expr *ListLiteral) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitListLiteralExpr(expr)
+}
+
+type Index struct {
+	scanner.Span
+	Object  Expression
+	Bracket scanner.Token
+	Index   Expression
+}
+
+func (expr *Index) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitIndexExpr(expr)
+}
+
+type SetIndex struct {
+	scanner.Span
+	Object  Expression
+	Bracket scanner.Token
+	Index   Expression
+	Value   Expression
+}
+
+func (expr *SetIndex) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitSetIndexExpr(expr)
+}
+
+type Length struct {
+	scanner.Span
+	Keyword scanner.Token
+	Object  Expression
+}
+
+func (expr *Length) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitLengthExpr(expr)
+}
+
+type MapLiteral struct {
+	scanner.Span
+	Brace  scanner.Token
+	Keys   []Expression
+	Values []Expression
+}
+
+func (expr *MapLiteral) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitMapLiteralExpr(expr)
+}
+
+type Keys struct {
+	scanner.Span
+	Keyword scanner.Token
+	Object  Expression
+}
+
+func (expr *Keys) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitKeysExpr(expr)
+}
+
+type RangeExpr struct {
+	scanner.Span
+	Start    Expression
+	Operator scanner.Token
+	End      Expression
+}
+
+func (expr *RangeExpr) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitRangeExpr(expr)
+}
+
+/*
+Interpolation is a string with embedded expressions. texts holds the text in front of every expression
+and one more entry for the text after the last expression, and formats holds each optional format specifier.
+*/
+type Interpolation struct {
+	scanner.Span
+	Texts       []string
+	Expressions []Expression
+	Formats     []*scanner.Token
+}
+
+func (expr *Interpolation) Accept(visitor VisitorExpr) interface{} {
+	return visitor.VisitInterpolationExpr(expr)
+}
@@ -0,0 +1,249 @@
+package ast
+
+import "github.com/idea456/psu-lang/scanner"
+
+type VisitorStmt interface {
+	VisitVariableStmt(stmt *VariableStmt)
+	VisitAssumeStmt(stmt *AssumeStmt)
+	VisitSayStmt(stmt *SayStmt)
+	VisitBlockStmt(stmt *BlockStmt)
+	VisitExprStmt(stmt *ExprStmt)
+	VisitIncrDecrStmt(stmt *IncrDecrStmt)
+	VisitIfStmt(stmt *IfStmt)
+	VisitWhileStmt(stmt *WhileStmt)
+	VisitForStmt(stmt *ForStmt)
+	VisitForEachStmt(stmt *ForEachStmt)
+	VisitDeleteStmt(stmt *DeleteStmt)
+	VisitProcedureStmt(stmt *ProcedureStmt)
+	VisitAlgorithmStmt(stmt *AlgorithmStmt)
+	VisitReturnStmt(stmt *ReturnStmt)
+	VisitBreakStmt(stmt *BreakStmt)
+	VisitContinueStmt(stmt *ContinueStmt)
+	VisitTryStmt(stmt *TryStmt)
+	VisitRaiseStmt(stmt *RaiseStmt)
+	VisitUseStmt(stmt *UseStmt)
+	VisitClassStmt(stmt *ClassStmt)
+}
+
+type Statement interface {
+	Accept(VisitorStmt)
+	Location() scanner.Span
+}
+
+type VariableStmt struct {
+	scanner.Span
+	Name        scanner.Token
+	Initializer Expression
+	Doc         string
+}
+
+func (stmt *VariableStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitVariableStmt(stmt)
+}
+
+type AssumeStmt struct {
+	scanner.Span
+	Name        scanner.Token
+	Initializer Expression
+	Doc         string
+}
+
+func (stmt *AssumeStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitAssumeStmt(stmt)
+}
+
+type SayStmt struct {
+	scanner.Span
+	Keyword     scanner.Token
+	Expressions []Expression
+	// printed between the expressions, a single space when nil
+	Separator Expression
+}
+
+func (stmt *SayStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitSayStmt(stmt)
+}
+
+type BlockStmt struct {
+	scanner.Span
+	Statements []Statement
+}
+
+func (stmt *BlockStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitBlockStmt(stmt)
+}
+
+type ExprStmt struct {
+	scanner.Span
+	Expression Expression
+}
+
+func (stmt *ExprStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitExprStmt(stmt)
+}
+
+type IncrDecrStmt struct {
+	scanner.Span
+	Target   Expression
+	Operator scanner.Token
+	Right    Expression
+}
+
+func (stmt *IncrDecrStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitIncrDecrStmt(stmt)
+}
+
+type IfStmt struct {
+	scanner.Span
+	Expression Expression
+	ThenBranch Statement
+	ElseBranch Statement
+}
+
+func (stmt *IfStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitIfStmt(stmt)
+}
+
+type WhileStmt struct {
+	scanner.Span
+	Condition Expression
+	Body      Statement
+}
+
+func (stmt *WhileStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitWhileStmt(stmt)
+}
+
+type ForStmt struct {
+	scanner.Span
+	Variable scanner.Token
+	Start    Expression
+	End      Expression
+	Step     Expression
+	Body     Statement
+}
+
+func (stmt *ForStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitForStmt(stmt)
+}
+
+type ForEachStmt struct {
+	scanner.Span
+	Variable   scanner.Token
+	Collection Expression
+	Body       Statement
+}
+
+func (stmt *ForEachStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitForEachStmt(stmt)
+}
+
+type ProcedureStmt struct {
+	scanner.Span
+	Name   scanner.Token
+	Params []scanner.Token
+	Body   []Statement
+	Doc    string
+}
+
+func (stmt *ProcedureStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitProcedureStmt(stmt)
+}
+
+/*
+AlgorithmStmt is a procedure which implicitly returns its declared outputs.
+*/
+type AlgorithmStmt struct {
+	scanner.Span
+	Declaration *ProcedureStmt
+	Outputs     []scanner.Token
+}
+
+func (stmt *AlgorithmStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitAlgorithmStmt(stmt)
+}
+
+type ReturnStmt struct {
+	scanner.Span
+	Keyword scanner.Token
+	Value   Expression
+}
+
+func (stmt *ReturnStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitReturnStmt(stmt)
+}
+
+type ClassStmt struct {
+	scanner.Span
+	Name       scanner.Token
+	Superclass *Variable
+	Methods    []*ProcedureStmt
+	Doc        string
+}
+
+func (stmt *ClassStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitClassStmt(stmt)
+}
+
+type DeleteStmt struct {
+	scanner.Span
+	Keyword scanner.Token
+	Target  *Index
+}
+
+func (stmt *DeleteStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitDeleteStmt(stmt)
+}
+
+type BreakStmt struct {
+	scanner.Span
+	Keyword scanner.Token
+}
+
+func (stmt *BreakStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitBreakStmt(stmt)
+}
+
+type ContinueStmt struct {
+	scanner.Span
+	Keyword scanner.Token
+}
+
+func (stmt *ContinueStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitContinueStmt(stmt)
+}
+
+type TryStmt struct {
+	scanner.Span
+	TryBranch     Statement
+	Name          *scanner.Token
+	CatchBranch   Statement
+	FinallyBranch Statement
+}
+
+func (stmt *TryStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitTryStmt(stmt)
+}
+
+type RaiseStmt struct {
+	scanner.Span
+	Keyword scanner.Token
+	Value   Expression
+	Kind    Expression
+}
+
+func (stmt *RaiseStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitRaiseStmt(stmt)
+}
+
+type UseStmt struct {
+	scanner.Span
+	Keyword scanner.Token
+	Path    scanner.Token
+	Alias   *scanner.Token
+	Names   []scanner.Token
+}
+
+func (stmt *UseStmt) Accept(visitor VisitorStmt) {
+	visitor.VisitUseStmt(stmt)
+}
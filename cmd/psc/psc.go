@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	pslang "github.com/idea456/psu-lang"
+)
+
+func getInput(reader *bufio.Reader) string {
+	t, _ := reader.ReadString('\n')
+	return strings.TrimSpace(t)
+}
+
+/*
+report prints an error with the source code it points at, coloured when printing to a terminal
+unless the NO_COLOR environment variable is set.
+*/
+func report(err error) {
+	var colour bool = os.Getenv("NO_COLOR") == ""
+	if info, statErr := os.Stdout.Stat(); statErr != nil || info.Mode()&os.ModeCharDevice == 0 {
+		colour = false
+	}
+	switch t := err.(type) {
+	case *pslang.SyntaxError:
+		fmt.Print(t.Render(colour))
+	case *pslang.RuntimeError:
+		fmt.Print(t.Render(colour))
+	case *os.PathError:
+		fmt.Println("Error, file not found!")
+	default:
+		fmt.Println(err)
+	}
+}
+
+func main() {
+	indentation := flag.Bool("indent", false, "use indentation instead of braces and semicolons for every input")
+	flag.Parse()
+
+	reader := bufio.NewReader(os.Stdin)
+	itpr := pslang.NewInterpreter()
+	itpr.SetIndentation(*indentation)
+	fmt.Print("PSU Language | psuc 1.0.0\n")
+	fmt.Print("Type exit to exit the program or press Ctrl-D.\n")
+
+	fmt.Print(">>> ")
+	line := getInput(reader)
+	for ; !strings.EqualFold("exit", line); line = getInput(reader) {
+		var err error
+		if strings.Split(line, " ")[0] == "file" {
+			err = itpr.RunFile(strings.Split(line, " ")[1])
+		} else {
+			err = itpr.Run(line)
+		}
+		if err != nil {
+			report(err)
+		}
+		fmt.Print(">>> ")
+	}
+	fmt.Print("Bai bai!\n")
+}
@@ -1 +1,209 @@
+/*
+Package error renders diagnostics, which are errors pointing at the source code they were raised for.
+A rendered diagnostic shows the offending line with the span underlined, followed by hints on how to fix it:
+
+	NameError at 'countr': undefined variable.
+	 --> test.psl:3:5
+	  |
+	3 | say countr;
+	  |     ^^^^^^
+	  = hint: did you mean 'counter'?
+*/
 package error
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Position is a place in the source code, where lines and columns count from 1 and columns count characters.
+*/
+type Position struct {
+	Line   int
+	Column int
+	Offset int
+}
+
+/*
+Span is the range of source code a diagnostic points at, where End is just past its last character.
+*/
+type Span struct {
+	Start Position
+	End   Position
+}
+
+type Diagnostic struct {
+	Kind    string
+	Message string
+	Span    Span
+	Hints   []string
+}
+
+/*
+Renderer renders diagnostics raised for a single source file. An empty path stands for code typed into the prompt.
+*/
+type Renderer struct {
+	Path   string
+	Source string
+	// colour output with ANSI escape codes, which should only be enabled when writing to a terminal
+	Colour bool
+}
+
+const (
+	reset = "\x1b[0m"
+	bold  = "\x1b[1m"
+	red   = "\x1b[31m"
+	blue  = "\x1b[34m"
+	cyan  = "\x1b[36m"
+)
+
+func NewRenderer(path string, source string, colour bool) *Renderer {
+	var renderer Renderer = Renderer{}
+	renderer.Path = path
+	renderer.Source = source
+	renderer.Colour = colour
+	return &renderer
+}
+
+func (r *Renderer) paint(colour string, text string) string {
+	if !r.Colour {
+		return text
+	}
+	return colour + text + reset
+}
+
+/*
+Render returns the diagnostic followed by the source line it points at. Spans over several lines are
+underlined up to the end of their first line, and spans which cover no characters get a single caret.
+*/
+func (r *Renderer) Render(d Diagnostic) string {
+	var builder strings.Builder
+	builder.WriteString(r.paint(bold+red, d.Kind) + r.paint(bold, ": "+d.Message) + "\n")
+
+	var path string = DisplayPath(r.Path)
+	var lines []string = strings.Split(r.Source, "\n")
+	var number string = strconv.Itoa(d.Span.Start.Line)
+	var gutter string = strings.Repeat(" ", len(number))
+	builder.WriteString(fmt.Sprintf("%s%s %s:%d:%d\n", gutter, r.paint(blue, "-->"), path, d.Span.Start.Line, d.Span.Start.Column))
+
+	if d.Span.Start.Line >= 1 && d.Span.Start.Line <= len(lines) {
+		var line []rune = []rune(strings.TrimRight(lines[d.Span.Start.Line-1], "\r"))
+		var start int = d.Span.Start.Column - 1
+		if start < 0 {
+			start = 0
+		}
+		if start > len(line) {
+			start = len(line)
+		}
+		var end int = len(line)
+		if d.Span.End.Line == d.Span.Start.Line && d.Span.End.Column-1 < end {
+			end = d.Span.End.Column - 1
+		}
+		var width int = end - start
+		if width < 1 {
+			width = 1
+		}
+
+		// tabs are kept in front of the carets, so that they line up with the source however tabs are displayed
+		var indent []rune = make([]rune, start)
+		for i := range indent {
+			indent[i] = ' '
+			if line[i] == '\t' {
+				indent[i] = '\t'
+			}
+		}
+
+		builder.WriteString(gutter + r.paint(blue, " |") + "\n")
+		builder.WriteString(r.paint(blue, number+" |") + " " + string(line) + "\n")
+		builder.WriteString(gutter + r.paint(blue, " |") + " " + string(indent) + r.paint(bold+red, strings.Repeat("^", width)) + "\n")
+	}
+
+	for _, hint := range d.Hints {
+		builder.WriteString(gutter + " " + r.paint(bold+cyan, "= hint:") + " " + hint + "\n")
+	}
+	return builder.String()
+}
+
+/*
+Levenshtein returns the number of characters which have to be inserted, deleted or replaced to turn a into b.
+*/
+func Levenshtein(a string, b string) int {
+	var source []rune = []rune(a)
+	var target []rune = []rune(b)
+	var previous []int = make([]int, len(target)+1)
+	var current []int = make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			var cost int = 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
+
+func min(values ...int) int {
+	var smallest int = values[0]
+	for _, value := range values[1:] {
+		if value < smallest {
+			smallest = value
+		}
+	}
+	return smallest
+}
+
+/*
+Suggest returns the candidate closest to a misspelled name. A candidate may differ in up to a third of the
+characters of the name, or in one character for short names, before it is too different to be what was meant.
+*/
+func Suggest(name string, candidates []string) (string, bool) {
+	var sorted []string = append([]string{}, candidates...)
+	sort.Strings(sorted)
+
+	var limit int = len([]rune(name)) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	var best string = ""
+	var found bool = false
+	for _, candidate := range sorted {
+		var distance int = Levenshtein(name, candidate)
+		if distance > 0 && distance <= limit {
+			best, limit, found = candidate, distance-1, true
+		}
+	}
+	return best, found
+}
+
+/*
+DidYouMean suggests the name closest to a misspelled one as a hint. Groups of candidates are tried in order,
+so that e.g. variables in scope can be preferred over keywords.
+*/
+func DidYouMean(name string, groups ...[]string) []string {
+	for _, candidates := range groups {
+		if suggestion, found := Suggest(name, candidates); found {
+			return []string{fmt.Sprintf("did you mean '%s'?", suggestion)}
+		}
+	}
+	return nil
+}
+
+/*
+DisplayPath names the file of a diagnostic, where an empty path stands for code typed into the prompt.
+*/
+func DisplayPath(path string) string {
+	if path == "" {
+		return "<input>"
+	}
+	return path
+}
@@ -0,0 +1,45 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Builtin is a procedure which is provided by the interpreter rather than declared in a program.
+*/
+type Builtin struct {
+	name     string
+	params   int
+	function func(itpr *Interpreter, paren scanner.Token, arguments []interface{}) interface{}
+}
+
+func (builtin *Builtin) arity() int {
+	return builtin.params
+}
+
+func (builtin *Builtin) call(itpr *Interpreter, paren scanner.Token, arguments []interface{}) interface{} {
+	return builtin.function(itpr, paren, arguments)
+}
+
+func (builtin *Builtin) String() string {
+	return fmt.Sprintf("<builtin procedure %s>", builtin.name)
+}
+
+/*
+defineBuiltins adds the builtin procedures to the top level environment of a program or module.
+*/
+func defineBuiltins(env *Environment) {
+	env.Define("format", &Builtin{
+		name:   "format",
+		params: 2,
+		function: func(itpr *Interpreter, paren scanner.Token, arguments []interface{}) interface{} {
+			spec, ok := arguments[1].(string)
+			if !ok {
+				runtimeErrorKind(TYPE_ERROR, paren.Span, stringify(arguments[1]), "format specifiers must be strings.")
+			}
+			return formatValue(paren, arguments[0], spec)
+		},
+	})
+}
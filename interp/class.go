@@ -0,0 +1,105 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+type Class struct {
+	name       string
+	superclass *Class
+	methods    map[string]*Procedure
+}
+
+func NewClass(name string, superclass *Class, methods map[string]*Procedure) *Class {
+	var class Class = Class{}
+	class.name = name
+	class.superclass = superclass
+	class.methods = methods
+	return &class
+}
+
+/*
+findMethod looks up the method on the class itself first before walking up the parent classes.
+*/
+func (class *Class) findMethod(name string) *Procedure {
+	if method, exists := class.methods[name]; exists {
+		return method
+	}
+	if class.superclass != nil {
+		return class.superclass.findMethod(name)
+	}
+	return nil
+}
+
+func (class *Class) arity() int {
+	if initializer := class.findMethod("init"); initializer != nil {
+		return initializer.arity()
+	}
+	return 0
+}
+
+/*
+Calling a class creates a new instance and runs its 'init' method, if any.
+*/
+func (class *Class) call(itpr *Interpreter, paren scanner.Token, arguments []interface{}) interface{} {
+	var instance *Instance = NewInstance(class)
+	if initializer := class.findMethod("init"); initializer != nil {
+		initializer.bind(instance).call(itpr, paren, arguments)
+	}
+	return instance
+}
+
+func (class *Class) String() string {
+	return fmt.Sprintf("<class %s>", class.name)
+}
+
+/*
+HasProperties is implemented by every runtime value whose properties can be read with ".".
+*/
+type HasProperties interface {
+	get(name scanner.Token) interface{}
+}
+
+/*
+HasFields is implemented by every runtime value whose fields can be assigned with ".".
+*/
+type HasFields interface {
+	HasProperties
+	set(name scanner.Token, value interface{})
+}
+
+type Instance struct {
+	class  *Class
+	fields map[string]interface{}
+}
+
+func NewInstance(class *Class) *Instance {
+	var instance Instance = Instance{}
+	instance.class = class
+	instance.fields = make(map[string]interface{})
+	return &instance
+}
+
+/*
+Fields shadow methods of the same name.
+*/
+func (instance *Instance) get(name scanner.Token) interface{} {
+	if value, exists := instance.fields[name.Lexeme]; exists {
+		return value
+	}
+	if method := instance.class.findMethod(name.Lexeme); method != nil {
+		return method.bind(instance)
+	}
+	runtimeErrorKind(NAME_ERROR, name.Span, name.Lexeme, "undefined property.")
+	return nil
+}
+
+func (instance *Instance) set(name scanner.Token, value interface{}) {
+	instance.fields[name.Lexeme] = value
+}
+
+func (instance *Instance) String() string {
+	return fmt.Sprintf("<%s instance>", instance.class.name)
+}
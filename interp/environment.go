@@ -0,0 +1,102 @@
+package interp
+
+import (
+	diagnostics "github.com/idea456/psu-lang/error"
+	"github.com/idea456/psu-lang/scanner"
+)
+
+type Environment struct {
+	values    map[string]interface{}
+	constants map[string]bool
+	enclosing *Environment
+}
+
+func NewEnv() *Environment {
+	var env Environment = Environment{}
+	env.values = make(map[string]interface{})
+	env.constants = make(map[string]bool)
+	env.enclosing = nil
+	return &env
+}
+
+func NewEnclosingEnv(enclosing *Environment) *Environment {
+	var env Environment = Environment{}
+	env.values = make(map[string]interface{})
+	env.constants = make(map[string]bool)
+	env.enclosing = enclosing
+	return &env
+}
+
+/*
+Get looks a variable up through the enclosing scopes. Undefined variables suggest the closest name in scope,
+or the closest keyword for statements which were misspelled.
+*/
+func (env *Environment) Get(name scanner.Token) interface{} {
+	if owner := env.resolve(name.Lexeme); owner != nil {
+		return owner.values[name.Lexeme]
+	}
+	var err *ErrorValue = NewErrorValue(NAME_ERROR, name.Span, name.Lexeme, "undefined variable.")
+	err.hints = diagnostics.DidYouMean(name.Lexeme, env.names(), scanner.KeywordNames())
+	panic(err)
+}
+
+/*
+Set assigns to the nearest enclosing scope which already holds the variable,
+otherwise the variable is declared in the current scope.
+*/
+func (env *Environment) Set(name scanner.Token, value interface{}) interface{} {
+	if owner := env.resolve(name.Lexeme); owner != nil {
+		if owner.constants[name.Lexeme] {
+			runtimeErrorKind(CONSTANT_ERROR, name.Span, name.Lexeme, "cannot reassign a constant declared with 'assume'.")
+		}
+		owner.values[name.Lexeme] = value
+		return value
+	}
+
+	env.values[name.Lexeme] = value
+	return value
+}
+
+/*
+Define always declares the variable in the current scope, shadowing any variable of the same name in enclosing scopes.
+*/
+func (env *Environment) Define(name string, value interface{}) {
+	env.values[name] = value
+}
+
+/*
+DefineConstant declares a variable in the current scope which can never be reassigned afterwards.
+*/
+func (env *Environment) DefineConstant(name scanner.Token, value interface{}) {
+	if env.constants[name.Lexeme] {
+		runtimeErrorKind(CONSTANT_ERROR, name.Span, name.Lexeme, "cannot redeclare a constant declared with 'assume'.")
+	}
+	env.values[name.Lexeme] = value
+	env.constants[name.Lexeme] = true
+}
+
+func (env *Environment) isConstant(name string) bool {
+	return env.constants[name]
+}
+
+/*
+names lists every variable visible from this scope.
+*/
+func (env *Environment) names() []string {
+	var names []string = make([]string, 0)
+	for scope := env; scope != nil; scope = scope.enclosing {
+		for name := range scope.values {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (env *Environment) resolve(name string) *Environment {
+	for scope := env; scope != nil; scope = scope.enclosing {
+		if _, exists := scope.values[name]; exists {
+			return scope
+		}
+	}
+	return nil
+}
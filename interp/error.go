@@ -0,0 +1,142 @@
+package interp
+
+import (
+	"fmt"
+
+	diagnostics "github.com/idea456/psu-lang/error"
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Kinds of runtime errors, which scripts can tell apart through the kind of a caught error.
+*/
+const (
+	RUNTIME_ERROR       = "RuntimeError"
+	TYPE_ERROR          = "TypeError"
+	NAME_ERROR          = "NameError"
+	INDEX_ERROR         = "IndexError"
+	KEY_ERROR           = "KeyError"
+	ZERO_DIVISION_ERROR = "ZeroDivisionError"
+	CONSTANT_ERROR      = "ConstantError"
+)
+
+/*
+ErrorValue is panicked by every runtime error and by raise statements, and is the value bound by catch.
+*/
+type ErrorValue struct {
+	kind    string
+	message string
+	line    int
+	lexeme  string
+	// value passed to raise, which is empty for built-in errors
+	value interface{}
+	// source code the error was raised for, and suggestions shown below it
+	span  scanner.Span
+	hints []string
+}
+
+func NewErrorValue(kind string, span scanner.Span, lexeme interface{}, message string) *ErrorValue {
+	var err ErrorValue = ErrorValue{}
+	err.kind = kind
+	err.message = message
+	err.line = span.Start.Line
+	err.lexeme = fmt.Sprint(lexeme)
+	err.span = span
+	return &err
+}
+
+/*
+runtimeError stops the interpreter with an error, which is turned into a *RuntimeError once it reaches Interpret
+unless a try statement catches it first.
+*/
+func runtimeError(span scanner.Span, lexeme interface{}, message string) {
+	runtimeErrorKind(RUNTIME_ERROR, span, lexeme, message)
+}
+
+func runtimeErrorKind(kind string, span scanner.Span, lexeme interface{}, message string) {
+	panic(NewErrorValue(kind, span, lexeme, message))
+}
+
+/*
+RuntimeError is returned when a script stops on an error which it did not catch.
+Kind is one of the built-in kinds such as TypeError, or the kind given to a raise statement.
+*/
+type RuntimeError struct {
+	Kind    string
+	Message string
+	Path    string
+	Line    int
+	Column  int
+	// value passed to raise, which is nil for built-in errors
+	Value interface{}
+	err   *ErrorValue
+}
+
+func NewRuntimeError(err *ErrorValue) *RuntimeError {
+	var runtime RuntimeError = RuntimeError{}
+	runtime.Kind = err.kind
+	runtime.Message = err.message
+	if err.span.Source != nil {
+		runtime.Path = err.span.Source.Path
+	}
+	runtime.Line = err.line
+	runtime.Column = err.span.Start.Column
+	runtime.Value = err.value
+	runtime.err = err
+	return &runtime
+}
+
+func (err *RuntimeError) Error() string {
+	var d diagnostics.Diagnostic = err.err.diagnostic()
+	return fmt.Sprintf("%s:%d:%d: %s: %s", diagnostics.DisplayPath(err.Path), err.Line, err.Column, d.Kind, d.Message)
+}
+
+/*
+Render shows the error with the line it was raised on, coloured with ANSI escape codes when asked to.
+Errors which were not raised for any source code are rendered on a single line.
+*/
+func (err *RuntimeError) Render(colour bool) string {
+	if err.err.span.Source == nil {
+		return err.err.Error() + "\n"
+	}
+	var renderer *diagnostics.Renderer = diagnostics.NewRenderer(err.Path, err.err.span.Source.Text, colour)
+	return renderer.Render(err.err.diagnostic())
+}
+
+func (err *ErrorValue) diagnostic() diagnostics.Diagnostic {
+	var kind string = err.kind
+	if kind == RUNTIME_ERROR {
+		kind = "Runtime Error"
+	}
+	var d diagnostics.Diagnostic = scanner.NewDiagnostic(kind, err.span, err.lexeme, err.message)
+	d.Hints = err.hints
+	return d
+}
+
+/*
+Fields of an error can be read by scripts as err.kind, err.message, err.line and err.value.
+*/
+func (err *ErrorValue) get(name scanner.Token) interface{} {
+	switch name.Lexeme {
+	case "kind":
+		return err.kind
+	case "message":
+		return err.message
+	case "line":
+		return err.line
+	case "value":
+		return err.value
+	}
+	runtimeErrorKind(NAME_ERROR, name.Span, name.Lexeme, "undefined property of error.")
+	return nil
+}
+
+func (err *ErrorValue) Error() string {
+	if err.kind == RUNTIME_ERROR {
+		return fmt.Sprintf("[Line %d] Runtime Error at '%s': %s", err.line, err.lexeme, err.message)
+	}
+	if err.lexeme == "" {
+		return fmt.Sprintf("[Line %d] %s: %s", err.line, err.kind, err.message)
+	}
+	return fmt.Sprintf("[Line %d] %s at '%s': %s", err.line, err.kind, err.lexeme, err.message)
+}
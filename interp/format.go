@@ -0,0 +1,129 @@
+package interp
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+formatSpec describes how a value is laid out by the format procedure and by "{value:spec}" interpolations.
+Specifiers are written as [[fill]align][width][.precision], where align is "<" for left, ">" for right
+or "^" for centred, and the precision is the number of decimal places of a number or the length of a string.
+*/
+type formatSpec struct {
+	fill      string
+	align     string
+	width     int
+	precision int
+}
+
+/*
+MAX_FORMAT_WIDTH bounds the width and precision of format specifiers, so that a mistyped specifier
+cannot make the interpreter allocate more memory than the host program has.
+*/
+const MAX_FORMAT_WIDTH = 1000
+
+func parseFormatSpec(token scanner.Token, spec string) formatSpec {
+	var format formatSpec = formatSpec{fill: " ", align: "", width: 0, precision: -1}
+	var rest string = spec
+
+	// an alignment may be preceded by the character used to fill up the width
+	first, size := utf8.DecodeRuneInString(rest)
+	if size > 0 && size < len(rest) && strings.ContainsRune("<>^", rune(rest[size])) {
+		format.fill = string(first)
+		format.align = rest[size : size+1]
+		rest = rest[size+1:]
+	} else if size > 0 && strings.ContainsRune("<>^", first) {
+		format.align = rest[:1]
+		rest = rest[1:]
+	}
+
+	var digits int = 0
+	for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+		digits += 1
+	}
+	if digits > 0 {
+		format.width = formatBound(token, spec, rest[:digits], "width")
+		rest = rest[digits:]
+	}
+
+	if strings.HasPrefix(rest, ".") {
+		digits = 1
+		for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits += 1
+		}
+		if digits == 1 {
+			runtimeErrorKind(TYPE_ERROR, token.Span, spec, "expected a precision after '.' in format specifier.")
+		}
+		format.precision = formatBound(token, spec, rest[1:digits], "precision")
+		rest = rest[digits:]
+	}
+
+	if rest != "" {
+		runtimeErrorKind(TYPE_ERROR, token.Span, spec, "invalid format specifier.")
+	}
+	return format
+}
+
+/*
+formatBound reads the digits of a width or precision, which may be at most MAX_FORMAT_WIDTH.
+*/
+func formatBound(token scanner.Token, spec string, digits string, name string) int {
+	bound, err := strconv.Atoi(digits)
+	if err != nil || bound > MAX_FORMAT_WIDTH {
+		runtimeErrorKind(TYPE_ERROR, token.Span, spec, fmt.Sprintf("format %s cannot be larger than %d.", name, MAX_FORMAT_WIDTH))
+	}
+	return bound
+}
+
+/*
+formatValue lays out a value according to a format specifier. Numbers are aligned to the right
+and everything else to the left, unless the specifier says otherwise.
+*/
+func formatValue(token scanner.Token, value interface{}, spec string) string {
+	var format formatSpec = parseFormatSpec(token, spec)
+	var text string
+	var align string = "<"
+
+	switch t := value.(type) {
+	case int, *big.Int, *Decimal, float64:
+		align = ">"
+		text = formatNumber(t)
+		real, isReal := t.(float64)
+		if format.precision < 0 || (isReal && (math.IsInf(real, 0) || math.IsNaN(real))) {
+			break
+		}
+		if isReal {
+			text = strconv.FormatFloat(real, 'f', format.precision, 64)
+		} else {
+			text = toRat(t).FloatString(format.precision)
+		}
+	default:
+		text = display(t)
+		if format.precision >= 0 && utf8.RuneCountInString(text) > format.precision {
+			text = string([]rune(text)[:format.precision])
+		}
+	}
+
+	if format.align != "" {
+		align = format.align
+	}
+	var padding int = format.width - utf8.RuneCountInString(text)
+	if padding <= 0 {
+		return text
+	}
+	switch align {
+	case ">":
+		return strings.Repeat(format.fill, padding) + text
+	case "^":
+		return strings.Repeat(format.fill, padding/2) + text + strings.Repeat(format.fill, padding-padding/2)
+	default:
+		return text + strings.Repeat(format.fill, padding)
+	}
+}
@@ -0,0 +1,155 @@
+package interp
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Value is a value handed between Go and a program. Values given to a program are converted into the values of the language:
+- nil, booleans, strings, any Go integer or floating point number, *big.Int and *big.Rat become empty, booleans, strings and numbers
+- slices and arrays become lists, and maps with string, number or boolean keys become maps, which are copies of the Go values
+- pointers to structs become objects whose exported fields and methods programs use with ".", which change the struct itself
+- functions become procedures, which convert their arguments into the types of their parameters
+Values read back from a program come in the following forms:
+- empty as nil, booleans as bool and strings as string
+- integers as int, or as *big.Int when they do not fit into an int
+- decimals and reals as float64
+- lists as []Value and maps as map[Value]Value, which are copies of the values in the program
+- objects as the pointers to structs and procedures made from Go functions as the functions they were made from
+- procedures, classes, instances and other values as they are, so that they can be handed back to the program
+*/
+type Value = interface{}
+
+/*
+NativeFunc is a Go function which programs can call like a procedure.
+Returning an error stops the program with a RuntimeError raised at the call, which can be caught.
+*/
+type NativeFunc func(args []Value) (Value, error)
+
+/*
+VARIADIC is the arity of native functions which accept any number of arguments.
+*/
+const VARIADIC = -1
+
+/*
+Define declares a global variable which programs can read and reassign, and which modules can read as well.
+*/
+func (itpr *Interpreter) Define(name string, value Value) error {
+	if !scanner.IsIdentifier(name) {
+		return fmt.Errorf("cannot define '%s', which is not a valid name.", name)
+	}
+	converted, err := toValue(value)
+	if err != nil {
+		return err
+	}
+	itpr.defined[name] = converted
+	itpr.environment.Define(name, converted)
+	return nil
+}
+
+/*
+RegisterFunc declares a global procedure which runs a Go function. Calls with a number of arguments other
+than the arity are rejected before the function runs, unless the arity is VARIADIC.
+*/
+func (itpr *Interpreter) RegisterFunc(name string, arity int, function NativeFunc) error {
+	if arity < 0 && arity != VARIADIC {
+		return fmt.Errorf("cannot register '%s' with a negative arity.", name)
+	}
+	return itpr.Define(name, &Builtin{
+		name:   name,
+		params: arity,
+		function: func(itpr *Interpreter, paren scanner.Token, arguments []interface{}) interface{} {
+			var args []Value = make([]Value, len(arguments))
+			for i, argument := range arguments {
+				args[i] = fromValue(argument)
+			}
+			result, err := function(args)
+			if err != nil {
+				runtimeError(paren.Span, name, err.Error())
+			}
+			converted, err := toValue(result)
+			if err != nil {
+				runtimeErrorKind(TYPE_ERROR, paren.Span, name, err.Error())
+			}
+			return converted
+		},
+	})
+}
+
+/*
+Get reads a global variable, such as one set by a program which has run, converted into a Go value.
+*/
+func (itpr *Interpreter) Get(name string) (Value, bool) {
+	value, exists := itpr.environment.values[name]
+	if !exists {
+		return nil, false
+	}
+	return fromValue(value), true
+}
+
+/*
+Globals returns every global variable converted into Go values, leaving out the builtin procedures of the language.
+*/
+func (itpr *Interpreter) Globals() map[string]Value {
+	var globals map[string]Value = make(map[string]Value)
+	for name, value := range itpr.environment.values {
+		if _, ok := value.(*Builtin); ok {
+			if _, hosted := itpr.defined[name]; !hosted {
+				continue
+			}
+		}
+		globals[name] = fromValue(value)
+	}
+	return globals
+}
+
+/*
+defineGlobals adds the builtin procedures and the globals defined by the host to the top level environment of a program or module.
+*/
+func (itpr *Interpreter) defineGlobals(env *Environment) {
+	defineBuiltins(env)
+	for name, value := range itpr.defined {
+		env.Define(name, value)
+	}
+}
+
+/*
+toValue converts a Go value into a value of the language.
+*/
+func toValue(value Value) (interface{}, error) {
+	return reflectValue(reflect.ValueOf(value))
+}
+
+/*
+fromValue converts a value of the language into a Go value.
+*/
+func fromValue(value interface{}) Value {
+	switch t := value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(t)
+	case *Decimal:
+		real, _ := t.value.Float64()
+		return real
+	case *List:
+		var elements []Value = make([]Value, len(t.elements))
+		for i, element := range t.elements {
+			elements[i] = fromValue(element)
+		}
+		return elements
+	case *HostObject:
+		return t.value.Interface()
+	case *HostFunction:
+		return t.function.Interface()
+	case *Map:
+		var entries map[Value]Value = make(map[Value]Value)
+		for _, key := range t.keys {
+			entries[fromValue(key)] = fromValue(t.values[t.key(scanner.Token{}, key)])
+		}
+		return entries
+	}
+	return value
+}
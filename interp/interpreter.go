@@ -0,0 +1,885 @@
+/*
+Package interp runs the syntax tree of package ast by walking it.
+*/
+package interp
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/idea456/psu-lang/ast"
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+BreakLoop and ContinueLoop are panicked by break and continue statements to unwind through
+nested blocks, and are recovered by the innermost loop.
+*/
+type BreakLoop struct{}
+
+type ContinueLoop struct{}
+
+type Interpreter struct {
+	environment *Environment
+	// file being run, which use statements resolve module paths against
+	path string
+	// loaded modules by absolute path, and the modules which are still being loaded
+	modules map[string]*Module
+	loading map[string]bool
+	// where say statements write to
+	output io.Writer
+	// whether source code run with Run and RunFile is written with indentation
+	indentation bool
+	// globals defined by the host program, which modules see as well
+	defined map[string]interface{}
+	// number of procedure calls which have not returned yet
+	depth int
+}
+
+/*
+MAX_CALL_DEPTH is how deeply procedure calls may nest before the program is stopped,
+well before runaway recursion would overflow the Go stack and crash the host program.
+*/
+const MAX_CALL_DEPTH = 5000
+
+func NewInterpreter() *Interpreter {
+	var itpr Interpreter = Interpreter{}
+	itpr.environment = NewEnv()
+	itpr.defined = make(map[string]interface{})
+	itpr.defineGlobals(itpr.environment)
+	itpr.path = ""
+	itpr.modules = make(map[string]*Module)
+	itpr.loading = make(map[string]bool)
+	itpr.output = os.Stdout
+	return &itpr
+}
+
+/*
+enterCall counts a procedure call which is about to run, raising an error which can be caught
+instead of recursing any deeper. Every call to enterCall is followed by a deferred call to leaveCall.
+*/
+func (itpr *Interpreter) enterCall(token scanner.Token) {
+	if itpr.depth >= MAX_CALL_DEPTH {
+		runtimeError(token.Span, token.Lexeme, fmt.Sprintf("calls are nested more than %d deep, most likely by a procedure calling itself without end.", MAX_CALL_DEPTH))
+	}
+	itpr.depth += 1
+}
+
+func (itpr *Interpreter) leaveCall() {
+	itpr.depth -= 1
+}
+
+/*
+SetPath sets the file which is about to be interpreted, an empty path stands for the current directory.
+*/
+func (itpr *Interpreter) SetPath(path string) {
+	itpr.path = path
+}
+
+/*
+SetOutput sets where say statements write to, which is the standard output unless changed.
+*/
+func (itpr *Interpreter) SetOutput(output io.Writer) {
+	itpr.output = output
+}
+
+/*
+Interpret runs the statements, returning a *RuntimeError when the script stops on an error which it does not catch,
+or a *SyntaxError when a module it uses cannot be parsed.
+*/
+func (itpr *Interpreter) Interpret(stmts []ast.Statement) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch t := r.(type) {
+			case *ErrorValue:
+				err = NewRuntimeError(t)
+			case *scanner.SyntaxError:
+				err = t
+			default:
+				err = NewRuntimeError(NewErrorValue(RUNTIME_ERROR, scanner.Span{}, "", fmt.Sprint(r)))
+			}
+		}
+	}()
+
+	// the file being run counts as loading, so that modules using it back are reported as circular
+	if abs, err := filepath.Abs(itpr.path); err == nil && itpr.path != "" {
+		itpr.loading[abs] = true
+		defer delete(itpr.loading, abs)
+	}
+
+	for _, stmt := range stmts {
+		itpr.execute(stmt)
+	}
+	return nil
+}
+
+// func (itpr *Interpreter) accept(visitor VisitorStmt) {
+// 	return
+// }
+
+// func (itpr *Interpreter) accept(visitor VisitorExpr) interface{} {
+// 	return 0
+// }
+
+func (itpr *Interpreter) evaluate(expr ast.Expression) interface{} {
+	return expr.Accept(itpr)
+}
+
+// execution for statements
+func (itpr *Interpreter) execute(stmt ast.Statement) {
+	stmt.Accept(itpr)
+}
+
+func (itpr *Interpreter) VisitLogicalExpr(expr *ast.Logical) interface{} {
+	var left interface{} = itpr.evaluate(expr.Left)
+	if expr.Operator.Type == scanner.OR {
+		if itpr.evaluateBool(left) {
+			return left
+		}
+	} else {
+		if !itpr.evaluateBool(left) {
+			return left
+		}
+	}
+	return itpr.evaluate(expr.Right)
+}
+
+func (itpr *Interpreter) VisitBinaryExpr(expr *ast.Binary) interface{} {
+	var left interface{} = itpr.evaluate(expr.Left)
+	var right interface{} = itpr.evaluate(expr.Right)
+
+	checkedComparison := false
+	switch expr.Operator.Type {
+	case scanner.PLUS:
+		if itpr.isString(left) && itpr.isString(right) {
+			return itpr.toString(left) + itpr.toString(right)
+		}
+		return itpr.arithmetic(expr.Operator, left, right)
+	case scanner.MINUS, scanner.STAR, scanner.SLASH, scanner.DIV, scanner.MODULUS:
+		return itpr.arithmetic(expr.Operator, left, right)
+	case scanner.EQUAL_EQUAL:
+		if left == nil || right == nil {
+			return false
+		}
+		return itpr.isEqual(left, right)
+	case scanner.NOT_EQUAL:
+		if left == nil || right == nil {
+			return false
+		}
+		return !itpr.isEqual(left, right)
+	case scanner.HAS:
+		m, ok := left.(*Map)
+		if !ok {
+			runtimeErrorKind(TYPE_ERROR, expr.Operator.Span, expr.Operator.Lexeme, "only maps can be checked for keys.")
+		}
+		return m.has(expr.Operator, right)
+	case scanner.GREATER:
+		// comparisons are only supported between strings and numbers
+		if itpr.isString(left) && itpr.isString(right) {
+			return itpr.toString(left) > itpr.toString(right)
+		}
+		if itpr.isNum(left) && itpr.isNum(right) {
+			return itpr.compareNumbers(left, right) > 0
+		}
+		checkedComparison = true
+	case scanner.GREATER_EQUAL:
+		if itpr.isString(left) && itpr.isString(right) {
+			return itpr.toString(left) >= itpr.toString(right)
+		}
+		if itpr.isNum(left) && itpr.isNum(right) {
+			return itpr.compareNumbers(left, right) >= 0
+		}
+		checkedComparison = true
+	case scanner.LESS:
+		if itpr.isString(left) && itpr.isString(right) {
+			return itpr.toString(left) < itpr.toString(right)
+		}
+		if itpr.isNum(left) && itpr.isNum(right) {
+			return itpr.compareNumbers(left, right) < 0
+		}
+		checkedComparison = true
+	case scanner.LESS_EQUAL:
+		if itpr.isString(left) && itpr.isString(right) {
+			return itpr.toString(left) <= itpr.toString(right)
+		}
+		if itpr.isNum(left) && itpr.isNum(right) {
+			return itpr.compareNumbers(left, right) <= 0
+		}
+		checkedComparison = true
+	}
+	if checkedComparison {
+		runtimeErrorKind(TYPE_ERROR, expr.Operator.Span, expr.Operator.Lexeme, "Error, expected string or integer for comparisons!")
+	}
+	return nil
+}
+
+func (itpr *Interpreter) VisitLiteralExpr(expr *ast.Literal) interface{} {
+	// decimal literals are scanned as exact fractions
+	if fraction, ok := expr.Value.(*big.Rat); ok {
+		return NewDecimal(fraction)
+	}
+	return expr.Value
+}
+
+func (itpr *Interpreter) VisitUnaryExpr(expr *ast.Unary) interface{} {
+	var right interface{} = itpr.evaluate(expr.Right)
+	switch expr.Operator.Type {
+	case scanner.MINUS:
+		itpr.checkNumbers(expr.Operator, right)
+		return itpr.negate(right)
+	case scanner.NOT:
+		return !itpr.evaluateBool(right)
+	}
+	return nil
+}
+
+func (itpr *Interpreter) VisitGroupExpr(expr *ast.Group) interface{} {
+	return itpr.evaluate(expr.Expression)
+}
+
+func (itpr *Interpreter) VisitCallExpr(expr *ast.Call) interface{} {
+	var callee interface{} = itpr.evaluate(expr.Callee)
+
+	var arguments []interface{} = make([]interface{}, 0)
+	for _, argument := range expr.Arguments {
+		arguments = append(arguments, itpr.evaluate(argument))
+	}
+
+	procedure, ok := callee.(Callable)
+	if !ok {
+		runtimeErrorKind(TYPE_ERROR, expr.Paren.Span, expr.Paren.Lexeme, "can only call procedures and classes.")
+	}
+	if procedure.arity() != VARIADIC && len(arguments) != procedure.arity() {
+		runtimeErrorKind(TYPE_ERROR, expr.Paren.Span, expr.Paren.Lexeme, fmt.Sprintf("expected %d arguments but got %d.", procedure.arity(), len(arguments)))
+	}
+
+	return procedure.call(itpr, expr.Paren, arguments)
+}
+
+func (itpr *Interpreter) VisitGetExpr(expr *ast.Get) interface{} {
+	object, ok := itpr.evaluate(expr.Object).(HasProperties)
+	if !ok {
+		runtimeErrorKind(TYPE_ERROR, expr.Name.Span, expr.Name.Lexeme, "only instances, objects, errors and modules have properties.")
+	}
+	return object.get(expr.Name)
+}
+
+func (itpr *Interpreter) VisitSetExpr(expr *ast.Set) interface{} {
+	object, ok := itpr.evaluate(expr.Object).(HasFields)
+	if !ok {
+		runtimeErrorKind(TYPE_ERROR, expr.Name.Span, expr.Name.Lexeme, "only instances and objects have fields.")
+	}
+	var value interface{} = itpr.evaluate(expr.Value)
+	object.set(expr.Name, value)
+	return value
+}
+
+func (itpr *Interpreter) VisitThisExpr(expr *ast.This) interface{} {
+	return (*itpr.environment).Get(expr.Keyword)
+}
+
+func (itpr *Interpreter) VisitParentExpr(expr *ast.Parent) interface{} {
+	var superclass *Class = (*itpr.environment).Get(expr.Keyword).(*Class)
+	var instance *Instance = (*itpr.environment).Get(scanner.Token{Type: scanner.THIS, Lexeme: "this", Line: expr.Keyword.Line, Span: expr.Keyword.Span}).(*Instance)
+
+	var method *Procedure = superclass.findMethod(expr.Method.Lexeme)
+	if method == nil {
+		runtimeErrorKind(NAME_ERROR, expr.Method.Span, expr.Method.Lexeme, "undefined parent method.")
+	}
+	return method.bind(instance)
+}
+
+func (itpr *Interpreter) VisitListLiteralExpr(expr *ast.ListLiteral) interface{} {
+	var elements []interface{} = make([]interface{}, 0)
+	for _, element := range expr.Elements {
+		elements = append(elements, itpr.evaluate(element))
+	}
+	return NewList(elements)
+}
+
+func (itpr *Interpreter) VisitIndexExpr(expr *ast.Index) interface{} {
+	var object Indexable = itpr.toIndexable(expr.Bracket, itpr.evaluate(expr.Object))
+	return object.get(expr.Bracket, itpr.evaluate(expr.Index))
+}
+
+func (itpr *Interpreter) VisitSetIndexExpr(expr *ast.SetIndex) interface{} {
+	var object Indexable = itpr.toIndexable(expr.Bracket, itpr.evaluate(expr.Object))
+	var index interface{} = itpr.evaluate(expr.Index)
+	var value interface{} = itpr.evaluate(expr.Value)
+	object.set(expr.Bracket, index, value)
+	return value
+}
+
+func (itpr *Interpreter) VisitMapLiteralExpr(expr *ast.MapLiteral) interface{} {
+	var m *Map = NewMap()
+	for i, key := range expr.Keys {
+		m.set(expr.Brace, itpr.evaluate(key), itpr.evaluate(expr.Values[i]))
+	}
+	return m
+}
+
+func (itpr *Interpreter) VisitRangeExpr(expr *ast.RangeExpr) interface{} {
+	return NewRange(expr.Operator, itpr.evaluate(expr.Start), itpr.evaluate(expr.End))
+}
+
+/*
+An interpolated string displays every embedded expression the way say would, unless it has a format specifier.
+*/
+func (itpr *Interpreter) VisitInterpolationExpr(expr *ast.Interpolation) interface{} {
+	var builder strings.Builder
+	for i, expression := range expr.Expressions {
+		builder.WriteString(expr.Texts[i])
+		var value interface{} = itpr.evaluate(expression)
+		if format := expr.Formats[i]; format != nil {
+			builder.WriteString(formatValue(*format, value, format.Lexeme))
+		} else {
+			builder.WriteString(display(value))
+		}
+	}
+	builder.WriteString(expr.Texts[len(expr.Texts)-1])
+	return builder.String()
+}
+
+func (itpr *Interpreter) VisitKeysExpr(expr *ast.Keys) interface{} {
+	m, ok := itpr.evaluate(expr.Object).(*Map)
+	if !ok {
+		runtimeErrorKind(TYPE_ERROR, expr.Keyword.Span, expr.Keyword.Lexeme, "can only take the keys of maps.")
+	}
+	var keys []interface{} = make([]interface{}, len(m.keys))
+	copy(keys, m.keys)
+	return NewList(keys)
+}
+
+func (itpr *Interpreter) VisitLengthExpr(expr *ast.Length) interface{} {
+	switch t := itpr.evaluate(expr.Object).(type) {
+	case *List:
+		return len(t.elements)
+	case *Map:
+		return len(t.keys)
+	case string:
+		return utf8.RuneCountInString(t)
+	default:
+		runtimeErrorKind(TYPE_ERROR, expr.Keyword.Span, expr.Keyword.Lexeme, "can only take the length of lists, maps and strings.")
+	}
+	return nil
+}
+
+func (itpr *Interpreter) VisitVariableExpr(expr *ast.Variable) interface{} {
+	return (*itpr.environment).Get(expr.Name)
+}
+
+/*
+A variable set without a value, as in "set x;", holds empty.
+*/
+func (itpr *Interpreter) VisitVariableStmt(stmt *ast.VariableStmt) {
+	var value interface{} = nil
+	if stmt.Initializer != nil {
+		value = itpr.evaluate(stmt.Initializer)
+	}
+	(*itpr.environment).Set(stmt.Name, value)
+}
+
+func (itpr *Interpreter) VisitAssumeStmt(stmt *ast.AssumeStmt) {
+	var value interface{} = itpr.evaluate(stmt.Initializer)
+	(*itpr.environment).DefineConstant(stmt.Name, value)
+}
+
+func (itpr *Interpreter) VisitSayStmt(stmt *ast.SayStmt) {
+	var texts []string = make([]string, 0)
+	for _, expression := range stmt.Expressions {
+		texts = append(texts, display(itpr.evaluate(expression)))
+	}
+
+	var separator string = " "
+	if stmt.Separator != nil {
+		text, ok := itpr.evaluate(stmt.Separator).(string)
+		if !ok {
+			runtimeErrorKind(TYPE_ERROR, stmt.Keyword.Span, stmt.Keyword.Lexeme, "say separators must be strings.")
+		}
+		separator = text
+	}
+	fmt.Fprintln(itpr.output, strings.Join(texts, separator))
+}
+
+func (itpr *Interpreter) VisitBlockStmt(blockStmt *ast.BlockStmt) {
+	itpr.executeBlock(blockStmt.Statements, NewEnclosingEnv(itpr.environment))
+}
+
+/*
+executeBlock runs the statements within the given environment, restoring the previous environment
+even when a statement unwinds early through a return.
+*/
+func (itpr *Interpreter) executeBlock(statements []ast.Statement, env *Environment) {
+	var enclosing *Environment = itpr.environment
+	defer func() {
+		itpr.environment = enclosing
+	}()
+
+	itpr.environment = env
+	for _, stmt := range statements {
+		itpr.execute(stmt)
+	}
+}
+
+func (itpr *Interpreter) VisitIfStmt(stmt *ast.IfStmt) {
+	if itpr.evaluateBool(itpr.evaluate(stmt.Expression)) {
+		itpr.execute(stmt.ThenBranch)
+	} else if stmt.ElseBranch != nil {
+		itpr.execute(stmt.ElseBranch)
+	}
+}
+
+func (itpr *Interpreter) VisitWhileStmt(stmt *ast.WhileStmt) {
+	var condition interface{} = itpr.evaluate(stmt.Condition)
+
+	for itpr.evaluateBool(condition) {
+		if itpr.executeLoopBody(stmt.Body) {
+			break
+		}
+		// re-evaluate the condition again after executing a statement in the body
+		condition = itpr.evaluate(stmt.Condition)
+	}
+}
+
+func (itpr *Interpreter) VisitProcedureStmt(stmt *ast.ProcedureStmt) {
+	itpr.checkNotConstant(stmt.Name)
+	(*itpr.environment).Define(stmt.Name.Lexeme, NewProcedure(stmt, itpr.environment, false))
+}
+
+func (itpr *Interpreter) VisitAlgorithmStmt(stmt *ast.AlgorithmStmt) {
+	itpr.checkNotConstant(stmt.Declaration.Name)
+	(*itpr.environment).Define(stmt.Declaration.Name.Lexeme, NewAlgorithm(stmt, itpr.environment))
+}
+
+func (itpr *Interpreter) VisitClassStmt(stmt *ast.ClassStmt) {
+	itpr.checkNotConstant(stmt.Name)
+	var superclass *Class = nil
+	if stmt.Superclass != nil {
+		parent, ok := itpr.evaluate(stmt.Superclass).(*Class)
+		if !ok {
+			runtimeErrorKind(TYPE_ERROR, stmt.Superclass.Name.Span, stmt.Superclass.Name.Lexeme, "parent must be a class.")
+		}
+		superclass = parent
+	}
+
+	// methods of a subclass close over an extra scope holding its parent class
+	var closure *Environment = itpr.environment
+	if superclass != nil {
+		closure = NewEnclosingEnv(itpr.environment)
+		closure.Define("parent", superclass)
+	}
+
+	var methods map[string]*Procedure = make(map[string]*Procedure)
+	for _, method := range stmt.Methods {
+		methods[method.Name.Lexeme] = NewProcedure(method, closure, method.Name.Lexeme == "init")
+	}
+
+	(*itpr.environment).Define(stmt.Name.Lexeme, NewClass(stmt.Name.Lexeme, superclass, methods))
+}
+
+func (itpr *Interpreter) VisitReturnStmt(stmt *ast.ReturnStmt) {
+	var value interface{} = nil
+	if stmt.Value != nil {
+		value = itpr.evaluate(stmt.Value)
+	}
+	panic(&ReturnValue{value: value, bare: stmt.Value == nil})
+}
+
+/*
+The range of a for loop is inclusive on both ends and counts downwards when the step is negative.
+The loop variable lives in its own scope around the body.
+*/
+func (itpr *Interpreter) VisitForStmt(stmt *ast.ForStmt) {
+	var start interface{} = itpr.evaluate(stmt.Start)
+	var end interface{} = itpr.evaluate(stmt.End)
+	var step interface{} = 1
+	if stmt.Step != nil {
+		step = itpr.evaluate(stmt.Step)
+	}
+
+	if !(itpr.isNum(start) && itpr.isNum(end) && itpr.isNum(step)) {
+		runtimeErrorKind(TYPE_ERROR, stmt.Variable.Span, stmt.Variable.Lexeme, "for loop ranges must be numbers.")
+	}
+	if itpr.compareNumbers(step, 0) == 0 {
+		runtimeError(stmt.Variable.Span, stmt.Variable.Lexeme, "for loop step cannot be 0.")
+	}
+
+	var enclosing *Environment = itpr.environment
+	defer func() {
+		itpr.environment = enclosing
+	}()
+	itpr.environment = NewEnclosingEnv(enclosing)
+
+	// keep whole numbers as integers so the loop variable compares equal to integer literals
+	startInt, startOk := start.(int)
+	endInt, endOk := end.(int)
+	stepInt, stepOk := step.(int)
+	if startOk && endOk && stepOk {
+		for i := startInt; (stepInt > 0 && i <= endInt) || (stepInt < 0 && i >= endInt); i += stepInt {
+			itpr.environment.Define(stmt.Variable.Lexeme, i)
+			if itpr.executeLoopBody(stmt.Body) {
+				return
+			}
+			// stepping past the largest or smallest int would wrap around, and would be past the end anyway
+			if (stepInt > 0 && i > MAX_INT-stepInt) || (stepInt < 0 && i < MIN_INT-stepInt) {
+				return
+			}
+		}
+		return
+	}
+
+	// otherwise step with the interpreter's own arithmetic so decimal steps stay exact
+	var plus scanner.Token = scanner.Token{Type: scanner.PLUS, Lexeme: "+", Line: stmt.Variable.Line, Span: stmt.Variable.Span}
+	var ascending bool = itpr.compareNumbers(step, 0) > 0
+	for i := start; (ascending && itpr.compareNumbers(i, end) <= 0) || (!ascending && itpr.compareNumbers(i, end) >= 0); i = itpr.arithmetic(plus, i, step) {
+		itpr.environment.Define(stmt.Variable.Lexeme, i)
+		if itpr.executeLoopBody(stmt.Body) {
+			return
+		}
+	}
+}
+
+/*
+Every iteration of a for each loop binds the loop variable in a fresh scope.
+*/
+func (itpr *Interpreter) VisitForEachStmt(stmt *ast.ForEachStmt) {
+	var iterator Iterator = itpr.iterator(stmt.Variable, itpr.evaluate(stmt.Collection))
+
+	var enclosing *Environment = itpr.environment
+	defer func() {
+		itpr.environment = enclosing
+	}()
+
+	for iterator.hasNext() {
+		itpr.environment = NewEnclosingEnv(enclosing)
+		itpr.environment.Define(stmt.Variable.Lexeme, iterator.next())
+		if itpr.executeLoopBody(stmt.Body) {
+			return
+		}
+	}
+}
+
+/*
+executeLoopBody runs a single iteration of a loop and reports whether a break statement ended the loop.
+A continue statement only ends the current iteration.
+*/
+func (itpr *Interpreter) executeLoopBody(body ast.Statement) (broke bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch r.(type) {
+			case *BreakLoop:
+				broke = true
+			case *ContinueLoop:
+				broke = false
+			default:
+				panic(r)
+			}
+		}
+	}()
+
+	itpr.execute(body)
+	return false
+}
+
+func (itpr *Interpreter) VisitBreakStmt(stmt *ast.BreakStmt) {
+	panic(&BreakLoop{})
+}
+
+func (itpr *Interpreter) VisitContinueStmt(stmt *ast.ContinueStmt) {
+	panic(&ContinueLoop{})
+}
+
+/*
+The finally branch runs however the try statement is left, including through return or break.
+Only errors are caught, so that return, break and continue pass through the catch branch.
+*/
+func (itpr *Interpreter) VisitTryStmt(stmt *ast.TryStmt) {
+	if stmt.FinallyBranch != nil {
+		defer itpr.execute(stmt.FinallyBranch)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(*ErrorValue)
+			if !ok || stmt.CatchBranch == nil {
+				panic(r)
+			}
+			var env *Environment = NewEnclosingEnv(itpr.environment)
+			if stmt.Name != nil {
+				env.Define(stmt.Name.Lexeme, err)
+			}
+			itpr.executeBlock([]ast.Statement{stmt.CatchBranch}, env)
+		}
+	}()
+
+	itpr.execute(stmt.TryBranch)
+}
+
+func (itpr *Interpreter) VisitRaiseStmt(stmt *ast.RaiseStmt) {
+	var value interface{} = itpr.evaluate(stmt.Value)
+	// errors caught earlier are raised again unchanged
+	if err, ok := value.(*ErrorValue); ok && stmt.Kind == nil {
+		panic(err)
+	}
+
+	var kind string = "Error"
+	if stmt.Kind != nil {
+		kind = fmt.Sprint(itpr.evaluate(stmt.Kind))
+	}
+	var message string = fmt.Sprint(value)
+	if err, ok := value.(*ErrorValue); ok {
+		message = err.message
+	}
+	var err *ErrorValue = NewErrorValue(kind, stmt.Location(), "", message)
+	err.value = value
+	panic(err)
+}
+
+/*
+A use statement either binds the whole module under a namespace or copies the listed names.
+*/
+func (itpr *Interpreter) VisitUseStmt(stmt *ast.UseStmt) {
+	// constants are checked before the module runs, so that a failing use has no effects
+	for _, name := range stmt.Names {
+		itpr.checkNotConstant(name)
+	}
+	if stmt.Alias != nil {
+		itpr.checkNotConstant(*stmt.Alias)
+	}
+
+	var path string = itpr.resolveModule(stmt.Keyword, stmt.Path.Literal.(string))
+	var module *Module = itpr.loadModule(stmt.Keyword, path)
+
+	if len(stmt.Names) > 0 {
+		for _, name := range stmt.Names {
+			(*itpr.environment).Define(name.Lexeme, module.get(name))
+		}
+		return
+	}
+
+	var namespace string = module.name
+	if stmt.Alias != nil {
+		namespace = stmt.Alias.Lexeme
+	} else if !scanner.IsIdentifier(namespace) {
+		runtimeError(stmt.Path.Span, namespace, "module name is not a valid identifier, name it with 'as'.")
+	} else {
+		itpr.checkNotConstant(scanner.Token{Type: scanner.IDENTIFIER, Lexeme: namespace, Line: stmt.Path.Line, Span: stmt.Path.Span})
+	}
+	(*itpr.environment).Define(namespace, module)
+}
+
+func (itpr *Interpreter) VisitExprStmt(stmt *ast.ExprStmt) {
+	itpr.evaluate(stmt.Expression)
+}
+
+func (itpr *Interpreter) VisitIncrDecrStmt(stmt *ast.IncrDecrStmt) {
+	var right interface{} = itpr.evaluate(stmt.Right)
+
+	// the target is only evaluated once, so it is read and written through the same object
+	switch target := stmt.Target.(type) {
+	case *ast.Variable:
+		var left interface{} = (*itpr.environment).Get(target.Name)
+		if owner := (*itpr.environment).resolve(target.Name.Lexeme); owner != nil && owner.isConstant(target.Name.Lexeme) {
+			runtimeErrorKind(CONSTANT_ERROR, target.Name.Span, target.Name.Lexeme, "cannot increment or decrement a constant declared with 'assume'.")
+		}
+		(*itpr.environment).Set(target.Name, itpr.incrDecr(stmt.Operator, left, right))
+	case *ast.Get:
+		object, ok := itpr.evaluate(target.Object).(HasFields)
+		if !ok {
+			runtimeErrorKind(TYPE_ERROR, target.Name.Span, target.Name.Lexeme, "only instances and objects have fields.")
+		}
+		object.set(target.Name, itpr.incrDecr(stmt.Operator, object.get(target.Name), right))
+	case *ast.Index:
+		var object Indexable = itpr.toIndexable(target.Bracket, itpr.evaluate(target.Object))
+		var index interface{} = itpr.evaluate(target.Index)
+		object.set(target.Bracket, index, itpr.incrDecr(stmt.Operator, object.get(target.Bracket, index), right))
+	}
+}
+
+func (itpr *Interpreter) VisitDeleteStmt(stmt *ast.DeleteStmt) {
+	m, ok := itpr.evaluate(stmt.Target.Object).(*Map)
+	if !ok {
+		runtimeErrorKind(TYPE_ERROR, stmt.Keyword.Span, stmt.Keyword.Lexeme, "can only delete entries of a map.")
+	}
+	m.delete(stmt.Target.Bracket, itpr.evaluate(stmt.Target.Index))
+}
+
+func (itpr *Interpreter) incrDecr(operator scanner.Token, left interface{}, right interface{}) interface{} {
+	if !(itpr.isNum(left) && itpr.isNum(right)) {
+		runtimeErrorKind(TYPE_ERROR, operator.Span, operator.Lexeme, "only numbers allowed for increments/decrements.")
+	}
+
+	var arithmetic scanner.Token = operator
+	arithmetic.Type = scanner.MINUS
+	if operator.Type == scanner.INCREMENT {
+		arithmetic.Type = scanner.PLUS
+	}
+	return itpr.arithmetic(arithmetic, left, right)
+}
+
+/*
+checkNotConstant stops procedures, classes and used modules from replacing a constant of the same scope.
+*/
+func (itpr *Interpreter) checkNotConstant(name scanner.Token) {
+	if (*itpr.environment).isConstant(name.Lexeme) {
+		runtimeErrorKind(CONSTANT_ERROR, name.Span, name.Lexeme, "cannot redeclare a constant declared with 'assume'.")
+	}
+}
+
+func (itpr *Interpreter) evaluateBool(expr interface{}) bool {
+	if expr == nil {
+		return false
+	}
+
+	truth, ok := expr.(bool)
+	if ok {
+		return truth
+	}
+	// assume all other values are true
+	return true
+}
+
+func (itpr *Interpreter) checkNumbers(operator scanner.Token, operands ...interface{}) {
+	for _, operand := range operands {
+		if !itpr.isNum(operand) {
+			runtimeErrorKind(TYPE_ERROR, operator.Span, operator.Lexeme, "operands must be numbers.")
+		}
+	}
+}
+
+func (itpr *Interpreter) toIndexable(token scanner.Token, expr interface{}) Indexable {
+	if text, ok := expr.(string); ok {
+		return characters(text)
+	}
+	object, ok := expr.(Indexable)
+	if !ok {
+		runtimeErrorKind(TYPE_ERROR, token.Span, token.Lexeme, "only lists, maps and strings can be indexed.")
+	}
+	return object
+}
+
+/*
+isEqual compares lists and maps by their contents, where maps are equal regardless of insertion order.
+*/
+func (itpr *Interpreter) isEqual(left interface{}, right interface{}) bool {
+	switch l := left.(type) {
+	case *List:
+		r, ok := right.(*List)
+		if !ok || len(l.elements) != len(r.elements) {
+			return false
+		}
+		for i := range l.elements {
+			if !itpr.isEqual(l.elements[i], r.elements[i]) {
+				return false
+			}
+		}
+		return true
+	case *Map:
+		r, ok := right.(*Map)
+		if !ok || len(l.keys) != len(r.keys) {
+			return false
+		}
+		for _, key := range l.keys {
+			var hash interface{} = l.key(scanner.Token{}, key)
+			value, exists := r.values[hash]
+			if !exists || !itpr.isEqual(l.values[hash], value) {
+				return false
+			}
+		}
+		return true
+	case *HostObject:
+		// objects are the same when they point at the same struct
+		r, ok := right.(*HostObject)
+		return ok && l.value.Type() == r.value.Type() && l.value.Pointer() == r.value.Pointer()
+	}
+	if itpr.isNum(left) && itpr.isNum(right) {
+		return itpr.compareNumbers(left, right) == 0
+	}
+	return left == right
+}
+
+/*
+stringify formats a value nested within a list, where strings are quoted to tell them apart.
+*/
+func stringify(value interface{}) string {
+	switch t := value.(type) {
+	case nil:
+		return "empty"
+	case string:
+		return strconv.Quote(t)
+	default:
+		return display(t)
+	}
+}
+
+/*
+display formats a value the way say prints it.
+*/
+func display(value interface{}) string {
+	switch t := value.(type) {
+	case nil:
+		return "empty"
+	case string:
+		return t
+	case int, *big.Int, *Decimal, float64:
+		return formatNumber(t)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+func (itpr *Interpreter) toString(expr interface{}) string {
+	text, ok := expr.(string)
+	if !ok {
+		runtimeErrorKind(TYPE_ERROR, scanner.Span{}, stringify(expr), "expected a string.")
+	}
+	return text
+}
+
+func (itpr *Interpreter) isString(expr interface{}) bool {
+	_, ok := expr.(string)
+	return ok
+}
+
+func (itpr *Interpreter) isNum(expr interface{}) bool {
+	switch expr.(type) {
+	case int, int8, int16, int32, int64, float32, float64, *big.Int, *Decimal:
+		return true
+	default:
+		return false
+	}
+}
+
+func (itpr *Interpreter) toNum(expr interface{}) float64 {
+	switch t := expr.(type) {
+	case int:
+		return float64(t)
+	case int8:
+		return float64(t)
+	case int16:
+		return float64(t)
+	case int32:
+		return float64(t)
+	case int64:
+		return float64(t)
+	case float32:
+		return float64(t)
+	case float64:
+		return float64(t)
+	case *big.Int:
+		real, _ := new(big.Float).SetInt(t).Float64()
+		return real
+	case *Decimal:
+		real, _ := t.value.Float64()
+		return real
+	default:
+		runtimeErrorKind(TYPE_ERROR, scanner.Span{}, stringify(expr), "expected a number.")
+		return 0
+	}
+}
@@ -0,0 +1,143 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Iterator walks through the elements of a collection for a for each loop.
+*/
+type Iterator interface {
+	hasNext() bool
+	next() interface{}
+}
+
+/*
+Range is an inclusive range of whole numbers such as 1..n, which is empty when the end is before the start.
+*/
+type Range struct {
+	start int
+	end   int
+}
+
+func NewRange(operator scanner.Token, start interface{}, end interface{}) *Range {
+	var r Range = Range{}
+	r.start = wholeNumber(operator, start)
+	r.end = wholeNumber(operator, end)
+	return &r
+}
+
+func (r *Range) String() string {
+	return fmt.Sprintf("%d..%d", r.start, r.end)
+}
+
+func wholeNumber(token scanner.Token, value interface{}) int {
+	if whole, ok := toWholeInt(value); ok {
+		return whole
+	}
+	runtimeErrorKind(TYPE_ERROR, token.Span, stringify(value), "ranges must be made of whole numbers.")
+	return 0
+}
+
+type sliceIterator struct {
+	elements []interface{}
+	position int
+}
+
+func (it *sliceIterator) hasNext() bool {
+	return it.position < len(it.elements)
+}
+
+func (it *sliceIterator) next() interface{} {
+	var element interface{} = it.elements[it.position]
+	it.position += 1
+	return element
+}
+
+type rangeIterator struct {
+	current int
+	end     int
+	// set once the end has been handed out, since stepping past the largest int would wrap around
+	done bool
+}
+
+func (it *rangeIterator) hasNext() bool {
+	return !it.done && it.current <= it.end
+}
+
+func (it *rangeIterator) next() interface{} {
+	var element int = it.current
+	if it.current == it.end {
+		it.done = true
+	} else {
+		it.current += 1
+	}
+	return element
+}
+
+/*
+instanceIterator lets instances of user-defined classes be looped over by defining
+the methods hasNext() and next().
+*/
+type instanceIterator struct {
+	itpr     *Interpreter
+	token    scanner.Token
+	instance *Instance
+}
+
+func (it *instanceIterator) hasNext() bool {
+	return it.itpr.evaluateBool(it.callMethod("hasNext"))
+}
+
+func (it *instanceIterator) next() interface{} {
+	return it.callMethod("next")
+}
+
+func (it *instanceIterator) callMethod(name string) interface{} {
+	var method *Procedure = it.instance.class.findMethod(name)
+	if method.arity() != 0 {
+		runtimeErrorKind(TYPE_ERROR, it.token.Span, name, "iterator methods cannot take arguments.")
+	}
+	return method.bind(it.instance).call(it.itpr, it.token, []interface{}{})
+}
+
+/*
+iterator returns an Iterator over list elements, map keys, string characters or range numbers.
+Instances are iterated either through their own hasNext() and next() methods,
+or through whatever their iterator() method returns.
+*/
+func (itpr *Interpreter) iterator(token scanner.Token, collection interface{}) Iterator {
+	switch t := collection.(type) {
+	case *List:
+		// iterate over a copy so that changing the list inside the loop does not skip elements
+		var elements []interface{} = make([]interface{}, len(t.elements))
+		copy(elements, t.elements)
+		return &sliceIterator{elements: elements}
+	case *Map:
+		var keys []interface{} = make([]interface{}, len(t.keys))
+		copy(keys, t.keys)
+		return &sliceIterator{elements: keys}
+	case string:
+		var characters []interface{} = make([]interface{}, 0)
+		for _, character := range t {
+			characters = append(characters, string(character))
+		}
+		return &sliceIterator{elements: characters}
+	case *Range:
+		return &rangeIterator{current: t.start, end: t.end}
+	case *Instance:
+		if method := t.class.findMethod("iterator"); method != nil {
+			// an iterator() returning an instance which is iterated through iterator() again must not recurse forever
+			itpr.enterCall(token)
+			defer itpr.leaveCall()
+			return itpr.iterator(token, method.bind(t).call(itpr, token, []interface{}{}))
+		}
+		if t.class.findMethod("hasNext") != nil && t.class.findMethod("next") != nil {
+			return &instanceIterator{itpr: itpr, token: token, instance: t}
+		}
+	}
+	runtimeErrorKind(TYPE_ERROR, token.Span, token.Lexeme, "can only loop over lists, maps, strings, ranges and iterable instances.")
+	return nil
+}
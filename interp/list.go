@@ -0,0 +1,107 @@
+package interp
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+type List struct {
+	elements []interface{}
+}
+
+func NewList(elements []interface{}) *List {
+	var list List = List{}
+	list.elements = elements
+	return &list
+}
+
+/*
+index checks that a value can be used as a position within a list or string of the given length and converts it to an int.
+Lists are indexed from 0, and whole numbers produced by arithmetic are accepted as indices.
+*/
+func index(bracket scanner.Token, position interface{}, length int) int {
+	var i int
+	switch t := position.(type) {
+	case int:
+		i = t
+	case *big.Int:
+		runtimeErrorKind(INDEX_ERROR, bracket.Span, stringify(t), "index out of range.")
+	case float64, *Decimal:
+		whole, ok := toWholeInt(t)
+		if !ok {
+			runtimeErrorKind(TYPE_ERROR, bracket.Span, stringify(t), "indices must be whole numbers.")
+		}
+		i = whole
+	default:
+		runtimeErrorKind(TYPE_ERROR, bracket.Span, position, "indices must be numbers or ranges.")
+	}
+
+	if i < 0 {
+		runtimeErrorKind(INDEX_ERROR, bracket.Span, i, "indices cannot be negative.")
+	}
+	if i >= length {
+		runtimeErrorKind(INDEX_ERROR, bracket.Span, i, "index out of range.")
+	}
+	return i
+}
+
+/*
+slice converts a range into the bounds of the elements it selects from a list or string of the given length.
+Ranges include their end, so a range ending before its start selects nothing.
+*/
+func slice(bracket scanner.Token, r *Range, length int) (int, int) {
+	if r.end < r.start {
+		if r.start < 0 || r.start > length {
+			runtimeErrorKind(INDEX_ERROR, bracket.Span, r.String(), "slice out of range.")
+		}
+		return r.start, r.start
+	}
+	if r.start < 0 || r.end >= length {
+		runtimeErrorKind(INDEX_ERROR, bracket.Span, r.String(), "slice out of range.")
+	}
+	return r.start, r.end + 1
+}
+
+/*
+Indexing a list with a range such as list[1..3] returns a new list with the selected elements.
+*/
+func (list *List) get(bracket scanner.Token, position interface{}) interface{} {
+	if r, ok := position.(*Range); ok {
+		start, end := slice(bracket, r, len(list.elements))
+		var elements []interface{} = make([]interface{}, end-start)
+		copy(elements, list.elements[start:end])
+		return NewList(elements)
+	}
+	return list.elements[index(bracket, position, len(list.elements))]
+}
+
+func (list *List) set(bracket scanner.Token, position interface{}, value interface{}) {
+	list.elements[index(bracket, position, len(list.elements))] = value
+}
+
+/*
+characters indexes a string by its characters rather than its bytes, so accented letters count once.
+*/
+type characters []rune
+
+func (text characters) get(bracket scanner.Token, position interface{}) interface{} {
+	if r, ok := position.(*Range); ok {
+		start, end := slice(bracket, r, len(text))
+		return string(text[start:end])
+	}
+	return string(text[index(bracket, position, len(text))])
+}
+
+func (text characters) set(bracket scanner.Token, position interface{}, value interface{}) {
+	runtimeErrorKind(TYPE_ERROR, bracket.Span, bracket.Lexeme, "strings cannot be changed.")
+}
+
+func (list *List) String() string {
+	var elements []string = make([]string, 0)
+	for _, element := range list.elements {
+		elements = append(elements, stringify(element))
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
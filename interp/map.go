@@ -0,0 +1,106 @@
+package interp
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Indexable is implemented by every runtime value which supports reading and writing with "[" "]".
+*/
+type Indexable interface {
+	get(bracket scanner.Token, key interface{}) interface{}
+	set(bracket scanner.Token, key interface{}, value interface{})
+}
+
+/*
+Map keeps its keys in insertion order so that printing and iterating a map is reproducible.
+Entries are looked up by a hash of the key, while keys keeps the values the user wrote.
+*/
+type Map struct {
+	keys   []interface{}
+	values map[interface{}]interface{}
+}
+
+func NewMap() *Map {
+	var m Map = Map{}
+	m.keys = make([]interface{}, 0)
+	m.values = make(map[interface{}]interface{})
+	return &m
+}
+
+/*
+numberKey identifies a fractional number by its exact value, so 0.5 and 1/2 find the same entry.
+*/
+type numberKey string
+
+/*
+key checks that a value can be used as a map key and returns the hash it is stored under.
+Whole numbers are stored as ints, so that a key computed by arithmetic finds the entry stored under an integer literal.
+*/
+func (m *Map) key(bracket scanner.Token, key interface{}) interface{} {
+	switch t := key.(type) {
+	case string, bool, int:
+		return t
+	case *big.Int, *Decimal, float64:
+		if whole, ok := toWholeInt(t); ok {
+			return whole
+		}
+		var exact *big.Rat = toRat(t)
+		if exact == nil {
+			runtimeErrorKind(TYPE_ERROR, bracket.Span, stringify(key), "map keys must be finite numbers.")
+		}
+		return numberKey(exact.RatString())
+	default:
+		runtimeErrorKind(TYPE_ERROR, bracket.Span, stringify(key), "map keys must be strings, numbers or booleans.")
+	}
+	return nil
+}
+
+func (m *Map) has(bracket scanner.Token, key interface{}) bool {
+	_, exists := m.values[m.key(bracket, key)]
+	return exists
+}
+
+func (m *Map) get(bracket scanner.Token, key interface{}) interface{} {
+	value, exists := m.values[m.key(bracket, key)]
+	if !exists {
+		runtimeErrorKind(KEY_ERROR, bracket.Span, stringify(key), "undefined key.")
+	}
+	return value
+}
+
+func (m *Map) set(bracket scanner.Token, key interface{}, value interface{}) {
+	var hash interface{} = m.key(bracket, key)
+	if _, exists := m.values[hash]; !exists {
+		if _, ok := hash.(numberKey); !ok {
+			key = hash
+		}
+		m.keys = append(m.keys, key)
+	}
+	m.values[hash] = value
+}
+
+func (m *Map) delete(bracket scanner.Token, key interface{}) {
+	var hash interface{} = m.key(bracket, key)
+	if _, exists := m.values[hash]; !exists {
+		runtimeErrorKind(KEY_ERROR, bracket.Span, stringify(key), "undefined key.")
+	}
+	delete(m.values, hash)
+	for i, k := range m.keys {
+		if m.key(bracket, k) == hash {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *Map) String() string {
+	var entries []string = make([]string, 0)
+	for _, key := range m.keys {
+		entries = append(entries, stringify(key)+": "+stringify(m.values[m.key(scanner.Token{}, key)]))
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
@@ -0,0 +1,123 @@
+package interp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Module holds the top level names of a file loaded with a use statement.
+*/
+type Module struct {
+	name string
+	env  *Environment
+}
+
+func NewModule(name string, env *Environment) *Module {
+	var module Module = Module{}
+	module.name = name
+	module.env = env
+	return &module
+}
+
+func (module *Module) get(name scanner.Token) interface{} {
+	value, exists := module.env.values[name.Lexeme]
+	if !exists {
+		runtimeErrorKind(NAME_ERROR, name.Span, name.Lexeme, fmt.Sprintf("module '%s' has no such name.", module.name))
+	}
+	return value
+}
+
+func (module *Module) String() string {
+	return fmt.Sprintf("<module %s>", module.name)
+}
+
+/*
+UsesIndentation reports whether a source file is written with indentation instead of braces, which is true for .pslg files.
+*/
+func UsesIndentation(path string) bool {
+	return filepath.Ext(path) == ".pslg"
+}
+
+/*
+resolveModule finds the file of a use statement, looking next to the importing file first
+and then within every directory listed in PSLPATH. The .pslg extension may be left out.
+*/
+func (itpr *Interpreter) resolveModule(keyword scanner.Token, name string) string {
+	var candidates []string = []string{name}
+	if filepath.Ext(name) == "" {
+		candidates = append(candidates, name+".pslg")
+	}
+
+	var dirs []string = make([]string, 0)
+	if filepath.IsAbs(name) {
+		dirs = append(dirs, "")
+	} else {
+		dirs = append(dirs, filepath.Dir(itpr.path))
+		for _, dir := range filepath.SplitList(os.Getenv("PSLPATH")) {
+			if dir != "" {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+
+	for _, dir := range dirs {
+		for _, candidate := range candidates {
+			var path string = filepath.Join(dir, candidate)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				if abs, err := filepath.Abs(path); err == nil {
+					return abs
+				}
+				return path
+			}
+		}
+	}
+	runtimeErrorKind(NAME_ERROR, keyword.Span, name, "module not found next to the file or in PSLPATH.")
+	return ""
+}
+
+/*
+loadModule runs a module file once in its own environment and caches it,
+so that every later use of the same file shares its top level names.
+*/
+func (itpr *Interpreter) loadModule(keyword scanner.Token, path string) *Module {
+	if module, exists := itpr.modules[path]; exists {
+		return module
+	}
+	if itpr.loading[path] {
+		runtimeError(keyword.Span, path, "circular use of modules.")
+	}
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		runtimeError(keyword.Span, path, "cannot read module.")
+	}
+
+	// syntax errors in a module are handed over to Interpret unchanged, pointing into the module
+	stmts, err := parse(path, string(bytes), itpr.indentation || UsesIndentation(path))
+	if err != nil {
+		panic(err)
+	}
+
+	// nested use statements are resolved relative to the module itself
+	var importer string = itpr.path
+	itpr.loading[path] = true
+	itpr.path = path
+	defer func() {
+		itpr.path = importer
+		delete(itpr.loading, path)
+	}()
+
+	var env *Environment = NewEnv()
+	itpr.defineGlobals(env)
+	itpr.executeBlock(stmts, env)
+
+	var name string = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	var module *Module = NewModule(name, env)
+	itpr.modules[path] = module
+	return module
+}
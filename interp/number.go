@@ -0,0 +1,342 @@
+package interp
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Numbers come in three kinds, from the most to the least exact:
+- integers, stored as int and promoted to *big.Int whenever a result does not fit into an int
+- decimals, stored as *Decimal, which are exact such that 0.1 + 0.2 == 0.3
+- reals, stored as float64, which only come from divisions that have no exact decimal result
+
+Arithmetic gives a result of the least exact kind among its operands.
+"/" divides into a decimal when the result can be written exactly and into a real otherwise,
+while "div" is integer division, truncating towards 0 like "%".
+*/
+type Decimal struct {
+	value *big.Rat
+}
+
+func NewDecimal(value *big.Rat) *Decimal {
+	var decimal Decimal = Decimal{}
+	decimal.value = value
+	return &decimal
+}
+
+func (decimal *Decimal) String() string {
+	return formatNumber(decimal)
+}
+
+/*
+MAX_INT and MIN_INT are the bounds of an int, past which integers are stored as *big.Int.
+*/
+const (
+	MAX_INT = int(^uint(0) >> 1)
+	MIN_INT = -MAX_INT - 1
+)
+
+/*
+normalizeInt turns integers which fit into an int back into an int.
+*/
+func normalizeInt(value *big.Int) interface{} {
+	if value.IsInt64() && int64(int(value.Int64())) == value.Int64() {
+		return int(value.Int64())
+	}
+	return value
+}
+
+func isInteger(value interface{}) bool {
+	switch value.(type) {
+	case int, *big.Int:
+		return true
+	}
+	return false
+}
+
+func isExact(value interface{}) bool {
+	switch value.(type) {
+	case int, *big.Int, *Decimal:
+		return true
+	}
+	return false
+}
+
+func toBigInt(value interface{}) *big.Int {
+	switch t := value.(type) {
+	case int:
+		return big.NewInt(int64(t))
+	case *big.Int:
+		return t
+	}
+	return nil
+}
+
+/*
+toRat converts any number into an exact fraction. Reals convert exactly as well, as long as they are finite.
+*/
+func toRat(value interface{}) *big.Rat {
+	switch t := value.(type) {
+	case int:
+		return new(big.Rat).SetInt64(int64(t))
+	case *big.Int:
+		return new(big.Rat).SetInt(t)
+	case *Decimal:
+		return t.value
+	case float64:
+		if math.IsInf(t, 0) || math.IsNaN(t) {
+			return nil
+		}
+		return new(big.Rat).SetFloat64(t)
+	}
+	return nil
+}
+
+/*
+isTerminating reports whether a fraction can be written with finitely many decimal places,
+which is when its denominator has no prime factors other than 2 and 5.
+*/
+func isTerminating(value *big.Rat) bool {
+	var denominator *big.Int = new(big.Int).Set(value.Denom())
+	var remainder *big.Int = new(big.Int)
+	for _, factor := range []int64{2, 5} {
+		var divisor *big.Int = big.NewInt(factor)
+		for {
+			quotient, rem := new(big.Int).QuoRem(denominator, divisor, remainder)
+			if rem.Sign() != 0 {
+				break
+			}
+			denominator = quotient
+		}
+	}
+	return denominator.Cmp(big.NewInt(1)) == 0
+}
+
+/*
+toWholeInt converts numbers without a fractional part into an int, reporting false for any other value.
+*/
+func toWholeInt(value interface{}) (int, bool) {
+	switch t := value.(type) {
+	case int:
+		return t, true
+	case *big.Int:
+		return 0, false
+	case float64:
+		if t == math.Trunc(t) && t >= math.MinInt64 && t < math.MaxInt64 {
+			return int(t), true
+		}
+	case *Decimal:
+		if t.value.IsInt() {
+			if integer, ok := normalizeInt(t.value.Num()).(int); ok {
+				return integer, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (itpr *Interpreter) arithmetic(operator scanner.Token, left interface{}, right interface{}) interface{} {
+	itpr.checkNumbers(operator, left, right)
+
+	if isInteger(left) && isInteger(right) {
+		return itpr.integerArithmetic(operator, left, right)
+	}
+	if operator.Type == scanner.DIV {
+		runtimeErrorKind(TYPE_ERROR, operator.Span, operator.Lexeme, "integer division needs integers, use '/' for decimals.")
+	}
+	if isExact(left) && isExact(right) {
+		return itpr.decimalArithmetic(operator, toRat(left), toRat(right), right)
+	}
+
+	var leftNum, rightNum float64 = itpr.toNum(left), itpr.toNum(right)
+	switch operator.Type {
+	case scanner.PLUS:
+		return leftNum + rightNum
+	case scanner.MINUS:
+		return leftNum - rightNum
+	case scanner.STAR:
+		return leftNum * rightNum
+	case scanner.SLASH:
+		if rightNum == 0 {
+			runtimeErrorKind(ZERO_DIVISION_ERROR, operator.Span, right, "cannot divide numbers by 0.")
+		}
+		return leftNum / rightNum
+	case scanner.MODULUS:
+		if rightNum == 0 {
+			runtimeErrorKind(ZERO_DIVISION_ERROR, operator.Span, right, "cannot modulus numbers by 0.")
+		}
+		return math.Mod(leftNum, rightNum)
+	}
+	return nil
+}
+
+/*
+integerArithmetic works on ints directly and only falls back to big integers when a result would overflow.
+*/
+func (itpr *Interpreter) integerArithmetic(operator scanner.Token, left interface{}, right interface{}) interface{} {
+	leftInt, leftIsInt := left.(int)
+	rightInt, rightIsInt := right.(int)
+	if leftIsInt && rightIsInt {
+		switch operator.Type {
+		case scanner.PLUS:
+			if sum := leftInt + rightInt; (sum > leftInt) == (rightInt > 0) {
+				return sum
+			}
+		case scanner.MINUS:
+			if difference := leftInt - rightInt; (difference < leftInt) == (rightInt > 0) {
+				return difference
+			}
+		case scanner.STAR:
+			if leftInt == 0 || rightInt == 0 {
+				return 0
+			}
+			if product := leftInt * rightInt; product/rightInt == leftInt && !(leftInt == -1 && rightInt == MIN_INT) && !(rightInt == -1 && leftInt == MIN_INT) {
+				return product
+			}
+		}
+	}
+
+	var leftBig, rightBig *big.Int = toBigInt(left), toBigInt(right)
+	switch operator.Type {
+	case scanner.PLUS:
+		return normalizeInt(new(big.Int).Add(leftBig, rightBig))
+	case scanner.MINUS:
+		return normalizeInt(new(big.Int).Sub(leftBig, rightBig))
+	case scanner.STAR:
+		return normalizeInt(new(big.Int).Mul(leftBig, rightBig))
+	case scanner.SLASH:
+		return itpr.decimalArithmetic(operator, new(big.Rat).SetInt(leftBig), new(big.Rat).SetInt(rightBig), right)
+	case scanner.DIV:
+		if rightBig.Sign() == 0 {
+			runtimeErrorKind(ZERO_DIVISION_ERROR, operator.Span, right, "cannot divide numbers by 0.")
+		}
+		return normalizeInt(new(big.Int).Quo(leftBig, rightBig))
+	case scanner.MODULUS:
+		if rightBig.Sign() == 0 {
+			runtimeErrorKind(ZERO_DIVISION_ERROR, operator.Span, right, "cannot modulus numbers by 0.")
+		}
+		return normalizeInt(new(big.Int).Rem(leftBig, rightBig))
+	}
+	return nil
+}
+
+func (itpr *Interpreter) decimalArithmetic(operator scanner.Token, left *big.Rat, right *big.Rat, rightValue interface{}) interface{} {
+	switch operator.Type {
+	case scanner.PLUS:
+		return NewDecimal(new(big.Rat).Add(left, right))
+	case scanner.MINUS:
+		return NewDecimal(new(big.Rat).Sub(left, right))
+	case scanner.STAR:
+		return NewDecimal(new(big.Rat).Mul(left, right))
+	case scanner.SLASH:
+		if right.Sign() == 0 {
+			runtimeErrorKind(ZERO_DIVISION_ERROR, operator.Span, rightValue, "cannot divide numbers by 0.")
+		}
+		var quotient *big.Rat = new(big.Rat).Quo(left, right)
+		if !isTerminating(quotient) {
+			real, _ := quotient.Float64()
+			return real
+		}
+		return NewDecimal(quotient)
+	case scanner.MODULUS:
+		if right.Sign() == 0 {
+			runtimeErrorKind(ZERO_DIVISION_ERROR, operator.Span, rightValue, "cannot modulus numbers by 0.")
+		}
+		// the remainder keeps the sign of the left side, the same as for integers
+		var quotient *big.Rat = new(big.Rat).Quo(left, right)
+		var truncated *big.Int = new(big.Int).Quo(quotient.Num(), quotient.Denom())
+		return NewDecimal(new(big.Rat).Sub(left, new(big.Rat).Mul(right, new(big.Rat).SetInt(truncated))))
+	}
+	return nil
+}
+
+func (itpr *Interpreter) negate(value interface{}) interface{} {
+	switch t := value.(type) {
+	case int:
+		if t == MIN_INT {
+			return new(big.Int).Neg(big.NewInt(int64(t)))
+		}
+		return -t
+	case *big.Int:
+		return normalizeInt(new(big.Int).Neg(t))
+	case *Decimal:
+		return NewDecimal(new(big.Rat).Neg(t.value))
+	}
+	return -itpr.toNum(value)
+}
+
+/*
+compareNumbers returns -1, 0 or 1 when the left number is smaller, equal or larger than the right number.
+Every kind of number is compared through its exact value.
+*/
+func (itpr *Interpreter) compareNumbers(left interface{}, right interface{}) int {
+	leftInt, leftIsInt := left.(int)
+	rightInt, rightIsInt := right.(int)
+	if leftIsInt && rightIsInt {
+		if leftInt < rightInt {
+			return -1
+		} else if leftInt > rightInt {
+			return 1
+		}
+		return 0
+	}
+
+	var leftRat, rightRat *big.Rat = toRat(left), toRat(right)
+	if leftRat == nil || rightRat == nil {
+		// only infinite reals cannot be turned into fractions
+		var leftNum, rightNum float64 = itpr.toNum(left), itpr.toNum(right)
+		if leftNum < rightNum {
+			return -1
+		} else if leftNum > rightNum {
+			return 1
+		}
+		return 0
+	}
+	return leftRat.Cmp(rightRat)
+}
+
+/*
+formatNumber prints numbers in plain decimal notation,
+keeping a ".0" on whole decimals and reals so that they can be told apart from integers.
+*/
+func formatNumber(value interface{}) string {
+	var text string
+	switch t := value.(type) {
+	case int:
+		return strconv.Itoa(t)
+	case *big.Int:
+		return t.String()
+	case *Decimal:
+		text = t.value.FloatString(decimalPlaces(t.value))
+	case float64:
+		if math.IsInf(t, 0) || math.IsNaN(t) {
+			return strconv.FormatFloat(t, 'g', -1, 64)
+		}
+		text = strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return ""
+	}
+	if !strings.Contains(text, ".") {
+		text += ".0"
+	}
+	return text
+}
+
+/*
+decimalPlaces counts the places after the decimal point needed to print a terminating fraction exactly.
+*/
+func decimalPlaces(value *big.Rat) int {
+	var places int = 0
+	var scaled *big.Rat = new(big.Rat).Set(value)
+	var ten *big.Rat = big.NewRat(10, 1)
+	for !scaled.IsInt() && places < 1000 {
+		scaled.Mul(scaled, ten)
+		places += 1
+	}
+	return places
+}
@@ -0,0 +1,86 @@
+package interp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+checkInteger compares an integer result against its exact value, which must be an int whenever it fits into one.
+*/
+func checkInteger(t *testing.T, name string, got interface{}, want *big.Int) {
+	t.Helper()
+	switch result := got.(type) {
+	case int:
+		if !want.IsInt64() || big.NewInt(int64(result)).Cmp(want) != 0 {
+			t.Errorf("%s = %d, want %s", name, result, want)
+		}
+	case *big.Int:
+		if result.Cmp(want) != 0 {
+			t.Errorf("%s = %s, want %s", name, result, want)
+		}
+		if normalized, isInt := normalizeInt(want).(int); isInt {
+			t.Errorf("%s = *big.Int %s, want int %d", name, result, normalized)
+		}
+	default:
+		t.Errorf("%s = %v (%T), want an integer", name, got, got)
+	}
+}
+
+func TestIntegerArithmeticAtIntBounds(t *testing.T) {
+	var operators = map[scanner.TokenType]func(z, x, y *big.Int) *big.Int{
+		scanner.PLUS:  (*big.Int).Add,
+		scanner.MINUS: (*big.Int).Sub,
+		scanner.STAR:  (*big.Int).Mul,
+	}
+	var lexemes = map[scanner.TokenType]string{scanner.PLUS: "+", scanner.MINUS: "-", scanner.STAR: "*"}
+
+	var tests = []struct {
+		left  int
+		right int
+	}{
+		{MAX_INT, 1},
+		{MAX_INT, 0},
+		{MAX_INT, -1},
+		{MAX_INT, MAX_INT},
+		{MAX_INT, MIN_INT},
+		{MIN_INT, 1},
+		{MIN_INT, 0},
+		{MIN_INT, -1},
+		{-1, MIN_INT},
+		{MIN_INT, MIN_INT},
+		{MIN_INT, MAX_INT},
+		{MAX_INT / 2, 2},
+		{MAX_INT/2 + 1, 2},
+		{MIN_INT / 2, 2},
+		{MIN_INT/2 - 1, 2},
+		{3037000500, 3037000500},
+		{-3037000500, 3037000500},
+		{0, MIN_INT},
+		{7, -3},
+	}
+
+	var itpr *Interpreter = NewInterpreter()
+	for _, test := range tests {
+		for tokenType, operate := range operators {
+			var operator scanner.Token = scanner.Token{Type: tokenType, Lexeme: lexemes[tokenType]}
+			var want *big.Int = operate(new(big.Int), big.NewInt(int64(test.left)), big.NewInt(int64(test.right)))
+			var got interface{} = itpr.integerArithmetic(operator, test.left, test.right)
+			checkInteger(t, big.NewInt(int64(test.left)).String()+" "+operator.Lexeme+" "+big.NewInt(int64(test.right)).String(), got, want)
+		}
+	}
+}
+
+func TestNegateAtIntBounds(t *testing.T) {
+	var itpr *Interpreter = NewInterpreter()
+	for _, value := range []int{0, 1, -1, MAX_INT, MIN_INT, MIN_INT + 1} {
+		var want *big.Int = new(big.Int).Neg(big.NewInt(int64(value)))
+		checkInteger(t, "-("+big.NewInt(int64(value)).String()+")", itpr.negate(value), want)
+	}
+
+	// negating a big integer which fits into an int again gives back an int
+	var beyond *big.Int = new(big.Int).Neg(big.NewInt(int64(MIN_INT)))
+	checkInteger(t, "-("+beyond.String()+")", itpr.negate(beyond), big.NewInt(int64(MIN_INT)))
+}
@@ -0,0 +1,466 @@
+package interp
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	diagnostics "github.com/idea456/psu-lang/error"
+	"github.com/idea456/psu-lang/scanner"
+)
+
+var errorType reflect.Type = reflect.TypeOf((*error)(nil)).Elem()
+var bigIntType reflect.Type = reflect.TypeOf((*big.Int)(nil))
+
+/*
+HostObject is a Go struct handed to a program through a pointer. Its exported fields are read and assigned
+with "." and its exported methods are called like procedures, so that the program works on the struct itself.
+*/
+type HostObject struct {
+	value reflect.Value
+}
+
+func NewHostObject(value reflect.Value) *HostObject {
+	var object HostObject = HostObject{}
+	object.value = value
+	return &object
+}
+
+/*
+Fields shadow methods of the same name. Fields holding a struct are handed out as host objects as well,
+so that assigning to their fields changes the struct they belong to.
+*/
+func (object *HostObject) get(name scanner.Token) interface{} {
+	if field, exists := object.field(name.Lexeme); exists {
+		converted, err := reflectValue(field)
+		if err != nil {
+			runtimeErrorKind(TYPE_ERROR, name.Span, name.Lexeme, err.Error())
+		}
+		return converted
+	}
+	if method := object.value.MethodByName(name.Lexeme); method.IsValid() {
+		return NewHostFunction(name.Lexeme, method)
+	}
+	object.undefined(name, "undefined property.")
+	return nil
+}
+
+func (object *HostObject) set(name scanner.Token, value interface{}) {
+	field, exists := object.field(name.Lexeme)
+	if !exists {
+		object.undefined(name, "undefined field.")
+	}
+	converted, err := goValue(value, field.Type())
+	if err != nil {
+		runtimeErrorKind(TYPE_ERROR, name.Span, name.Lexeme, err.Error())
+	}
+	field.Set(converted)
+}
+
+/*
+field looks up an exported field, including the fields of embedded structs.
+*/
+func (object *HostObject) field(name string) (reflect.Value, bool) {
+	var structure reflect.Value = object.value.Elem()
+	field, exists := structure.Type().FieldByName(name)
+	if !exists || field.PkgPath != "" {
+		return reflect.Value{}, false
+	}
+	for _, i := range field.Index {
+		if structure.Kind() == reflect.Ptr {
+			// fields promoted through an embedded pointer which was never set do not exist yet
+			if structure.IsNil() {
+				return reflect.Value{}, false
+			}
+			structure = structure.Elem()
+		}
+		structure = structure.Field(i)
+	}
+	return structure, structure.CanSet()
+}
+
+func (object *HostObject) undefined(name scanner.Token, message string) {
+	var err *ErrorValue = NewErrorValue(NAME_ERROR, name.Span, name.Lexeme, message)
+	err.hints = diagnostics.DidYouMean(name.Lexeme, object.names())
+	panic(err)
+}
+
+/*
+names lists the exported fields and methods of the struct, which are suggested for misspelled properties.
+*/
+func (object *HostObject) names() []string {
+	var names []string = make([]string, 0)
+	var structure reflect.Type = object.value.Type().Elem()
+	for i := 0; i < structure.NumField(); i++ {
+		if structure.Field(i).PkgPath == "" {
+			names = append(names, structure.Field(i).Name)
+		}
+	}
+	for i := 0; i < object.value.Type().NumMethod(); i++ {
+		names = append(names, object.value.Type().Method(i).Name)
+	}
+	return names
+}
+
+func (object *HostObject) String() string {
+	if stringer, ok := object.value.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("<%s object>", object.value.Type().Elem().Name())
+}
+
+/*
+HostFunction is a Go function or method which programs call like a procedure. Arguments are converted into
+the types of its parameters, and a final error result is raised in the program instead of being returned.
+*/
+type HostFunction struct {
+	name     string
+	function reflect.Value
+}
+
+func NewHostFunction(name string, function reflect.Value) *HostFunction {
+	var hostFunction HostFunction = HostFunction{}
+	hostFunction.name = name
+	hostFunction.function = function
+	return &hostFunction
+}
+
+func (hostFunction *HostFunction) arity() int {
+	if hostFunction.function.Type().IsVariadic() {
+		return VARIADIC
+	}
+	return hostFunction.function.Type().NumIn()
+}
+
+func (hostFunction *HostFunction) call(itpr *Interpreter, paren scanner.Token, arguments []interface{}) interface{} {
+	var signature reflect.Type = hostFunction.function.Type()
+	if signature.IsVariadic() && len(arguments) < signature.NumIn()-1 {
+		runtimeErrorKind(TYPE_ERROR, paren.Span, paren.Lexeme, fmt.Sprintf("expected at least %d arguments but got %d.", signature.NumIn()-1, len(arguments)))
+	}
+
+	var in []reflect.Value = make([]reflect.Value, len(arguments))
+	for i, argument := range arguments {
+		var parameter reflect.Type
+		if signature.IsVariadic() && i >= signature.NumIn()-1 {
+			parameter = signature.In(signature.NumIn() - 1).Elem()
+		} else {
+			parameter = signature.In(i)
+		}
+		converted, err := goValue(argument, parameter)
+		if err != nil {
+			runtimeErrorKind(TYPE_ERROR, paren.Span, hostFunction.name, fmt.Sprintf("argument %d: %s", i+1, err.Error()))
+		}
+		in[i] = converted
+	}
+
+	var results []reflect.Value = hostFunction.function.Call(in)
+	if count := len(results); count > 0 && signature.Out(count-1) == errorType {
+		if !results[count-1].IsNil() {
+			runtimeError(paren.Span, hostFunction.name, results[count-1].Interface().(error).Error())
+		}
+		results = results[:count-1]
+	}
+
+	// several results are returned together, the same way as the outputs of an algorithm
+	var values []interface{} = make([]interface{}, len(results))
+	for i, result := range results {
+		converted, err := reflectValue(result)
+		if err != nil {
+			runtimeErrorKind(TYPE_ERROR, paren.Span, hostFunction.name, err.Error())
+		}
+		values[i] = converted
+	}
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	default:
+		return NewList(values)
+	}
+}
+
+func (hostFunction *HostFunction) String() string {
+	return fmt.Sprintf("<builtin procedure %s>", hostFunction.name)
+}
+
+/*
+functionName names a Go function after its declaration, leaving out the package it was declared in.
+*/
+func functionName(function reflect.Value) string {
+	var name string = "function"
+	if declared := runtime.FuncForPC(function.Pointer()); declared != nil {
+		name = declared.Name()
+	}
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+/*
+reflectValue converts a Go value into a value of the language. Pointers to structs become host objects,
+and structs reached through a pointer are kept in place so that programs can change them.
+*/
+func reflectValue(value reflect.Value) (interface{}, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+	if value.CanInterface() {
+		switch t := value.Interface().(type) {
+		case nil, bool, string, int, float64, *Decimal, *List, *Map, *Range, *ErrorValue, *Instance, *Module, *HostObject, Callable:
+			return t, nil
+		case *big.Int:
+			if t == nil {
+				return nil, nil
+			}
+			return normalizeInt(new(big.Int).Set(t)), nil
+		case *big.Rat:
+			if t == nil {
+				return nil, nil
+			}
+			if t.IsInt() {
+				return normalizeInt(new(big.Int).Set(t.Num())), nil
+			}
+			return NewDecimal(new(big.Rat).Set(t)), nil
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return normalizeInt(big.NewInt(value.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return normalizeInt(new(big.Int).SetUint64(value.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return reflectValue(value.Elem())
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil, nil
+		}
+		if value.Elem().Kind() == reflect.Struct {
+			return NewHostObject(value), nil
+		}
+		return reflectValue(value.Elem())
+	case reflect.Struct:
+		if value.CanAddr() {
+			return reflectValue(value.Addr())
+		}
+		var copied reflect.Value = reflect.New(value.Type())
+		copied.Elem().Set(value)
+		return reflectValue(copied)
+	case reflect.Func:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return NewHostFunction(functionName(value), value), nil
+	case reflect.Slice, reflect.Array:
+		var elements []interface{} = make([]interface{}, value.Len())
+		for i := range elements {
+			converted, err := reflectValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = converted
+		}
+		return NewList(elements), nil
+	case reflect.Map:
+		keys, err := sortedKeys(value)
+		if err != nil {
+			return nil, err
+		}
+		var m *Map = NewMap()
+		for _, key := range keys {
+			convertedKey, err := reflectValue(key)
+			if err != nil {
+				return nil, err
+			}
+			converted, err := reflectValue(value.MapIndex(key))
+			if err != nil {
+				return nil, err
+			}
+			m.set(scanner.Token{}, convertedKey, converted)
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("cannot convert a Go value of type %s.", value.Type())
+}
+
+/*
+sortedKeys returns the keys of a Go map in order, since Go maps have no order of their own while maps
+of the language keep the order in which keys were added.
+*/
+func sortedKeys(m reflect.Value) ([]reflect.Value, error) {
+	var keys []reflect.Value = m.MapKeys()
+	var less func(i int, j int) bool
+	switch m.Type().Key().Kind() {
+	case reflect.String:
+		less = func(i int, j int) bool { return keys[i].String() < keys[j].String() }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(i int, j int) bool { return keys[i].Int() < keys[j].Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		less = func(i int, j int) bool { return keys[i].Uint() < keys[j].Uint() }
+	case reflect.Float32, reflect.Float64:
+		less = func(i int, j int) bool { return keys[i].Float() < keys[j].Float() }
+	case reflect.Bool:
+		less = func(i int, j int) bool { return !keys[i].Bool() && keys[j].Bool() }
+	case reflect.Interface:
+		// keys of mixed types are ordered by how they are printed, after checking that each can be a key
+		for _, key := range keys {
+			switch key.Elem().Kind() {
+			case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			default:
+				return nil, fmt.Errorf("cannot convert a Go map with the key %v.", key)
+			}
+		}
+		less = func(i int, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) }
+	default:
+		return nil, fmt.Errorf("cannot convert a Go map with keys of type %s.", m.Type().Key())
+	}
+	sort.Slice(keys, less)
+	return keys, nil
+}
+
+/*
+goValue converts a value of the language into a Go value of the given type, reporting an error
+when the value does not fit, such as a real handed to an int or a list handed to a string.
+*/
+func goValue(value interface{}, typ reflect.Type) (reflect.Value, error) {
+	var result reflect.Value = reflect.New(typ).Elem()
+	var mismatch error = fmt.Errorf("expected a Go %s but got %s.", typ, stringify(value))
+
+	if value == nil {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Func:
+			return result, nil
+		}
+		return result, mismatch
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		boolean, ok := value.(bool)
+		if !ok {
+			return result, mismatch
+		}
+		result.SetBool(boolean)
+	case reflect.String:
+		text, ok := value.(string)
+		if !ok {
+			return result, mismatch
+		}
+		result.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var exact *big.Rat = toRat(value)
+		if exact == nil || !exact.IsInt() {
+			return result, mismatch
+		}
+		if !exact.Num().IsInt64() || result.OverflowInt(exact.Num().Int64()) {
+			return result, fmt.Errorf("%s is too large for a Go %s.", stringify(value), typ)
+		}
+		result.SetInt(exact.Num().Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var exact *big.Rat = toRat(value)
+		if exact == nil || !exact.IsInt() {
+			return result, mismatch
+		}
+		if exact.Sign() < 0 || !exact.Num().IsUint64() || result.OverflowUint(exact.Num().Uint64()) {
+			return result, fmt.Errorf("%s does not fit into a Go %s.", stringify(value), typ)
+		}
+		result.SetUint(exact.Num().Uint64())
+	case reflect.Float32, reflect.Float64:
+		var real float64
+		if float, ok := value.(float64); ok {
+			real = float
+		} else if exact := toRat(value); exact != nil {
+			real, _ = exact.Float64()
+		} else {
+			return result, mismatch
+		}
+		if result.OverflowFloat(real) {
+			return result, fmt.Errorf("%s is too large for a Go %s.", stringify(value), typ)
+		}
+		result.SetFloat(real)
+	case reflect.Slice, reflect.Array:
+		list, ok := value.(*List)
+		if !ok {
+			return result, mismatch
+		}
+		if typ.Kind() == reflect.Slice {
+			result = reflect.MakeSlice(typ, len(list.elements), len(list.elements))
+		} else if len(list.elements) != typ.Len() {
+			return result, fmt.Errorf("expected a list of %d elements for a Go %s but got %d.", typ.Len(), typ, len(list.elements))
+		}
+		for i, element := range list.elements {
+			converted, err := goValue(element, typ.Elem())
+			if err != nil {
+				return result, err
+			}
+			result.Index(i).Set(converted)
+		}
+	case reflect.Map:
+		m, ok := value.(*Map)
+		if !ok {
+			return result, mismatch
+		}
+		result = reflect.MakeMapWithSize(typ, len(m.keys))
+		for _, key := range m.keys {
+			convertedKey, err := goValue(key, typ.Key())
+			if err != nil {
+				return result, err
+			}
+			converted, err := goValue(m.values[m.key(scanner.Token{}, key)], typ.Elem())
+			if err != nil {
+				return result, err
+			}
+			result.SetMapIndex(convertedKey, converted)
+		}
+	case reflect.Ptr:
+		if object, ok := value.(*HostObject); ok && object.value.Type().AssignableTo(typ) {
+			return object.value, nil
+		}
+		if typ == bigIntType && isInteger(value) {
+			return reflect.ValueOf(toRat(value).Num()), nil
+		}
+		// other pointers are given a fresh copy of the value they point at
+		converted, err := goValue(value, typ.Elem())
+		if err != nil {
+			return result, mismatch
+		}
+		result = reflect.New(typ.Elem())
+		result.Elem().Set(converted)
+	case reflect.Struct:
+		object, ok := value.(*HostObject)
+		if !ok || object.value.Type().Elem() != typ {
+			return result, mismatch
+		}
+		result.Set(object.value.Elem())
+	case reflect.Interface:
+		var converted reflect.Value = reflect.ValueOf(fromValue(value))
+		if !converted.IsValid() {
+			return result, nil
+		}
+		if !converted.Type().AssignableTo(typ) {
+			return result, mismatch
+		}
+		result.Set(converted)
+	case reflect.Func:
+		hostFunction, ok := value.(*HostFunction)
+		if !ok || !hostFunction.function.Type().AssignableTo(typ) {
+			return result, mismatch
+		}
+		return hostFunction.function, nil
+	default:
+		return result, mismatch
+	}
+	return result, nil
+}
@@ -0,0 +1,221 @@
+package interp
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testStats struct {
+	Wins int
+}
+
+type testPlayer struct {
+	Name   string
+	Score  int
+	Small  int8
+	Count  uint
+	Health float64
+	Alive  bool
+	Tags   []string
+	Items  map[string]int
+	Pair   [2]int
+	Stats  testStats
+	Friend *testPlayer
+	hidden int
+}
+
+func (player *testPlayer) Heal(amount float64) float64 {
+	player.Health += amount
+	return player.Health
+}
+
+func (player *testPlayer) Check(n int) (int, error) {
+	if n < 0 {
+		return 0, errors.New("negative checks are not allowed.")
+	}
+	return n * 2, nil
+}
+
+func TestGoValue(t *testing.T) {
+	var player *testPlayer = &testPlayer{Name: "ann"}
+	var tests = []struct {
+		value interface{}
+		typ   interface{}
+		want  interface{}
+		// part of the error message, when the conversion fails
+		err string
+	}{
+		{3, int(0), 3, ""},
+		{NewDecimal(big.NewRat(6, 2)), int(0), 3, ""},
+		{NewDecimal(big.NewRat(3, 2)), int(0), nil, "expected a Go int but got 1.5."},
+		{"3", int(0), nil, "expected a Go int but got \"3\"."},
+		{128, int8(0), nil, "128 is too large for a Go int8."},
+		{-128, int8(0), int8(-128), ""},
+		{new(big.Int).Lsh(big.NewInt(1), 64), int(0), nil, "is too large for a Go int."},
+		{-1, uint(0), nil, "-1 does not fit into a Go uint."},
+		{2, float64(0), 2.0, ""},
+		{NewDecimal(big.NewRat(1, 4)), float64(0), 0.25, ""},
+		{1e300, float32(0), nil, "is too large for a Go float32."},
+		{true, false, true, ""},
+		{1, false, nil, "expected a Go bool but got 1."},
+		{"hi", "", "hi", ""},
+		{nil, "", nil, "expected a Go string but got empty."},
+		{NewList([]interface{}{"a", "b"}), []string{}, []string{"a", "b"}, ""},
+		{NewList([]interface{}{"a", 1}), []string{}, nil, "expected a Go string but got 1."},
+		{NewList([]interface{}{1, 2}), [2]int{}, [2]int{1, 2}, ""},
+		{NewList([]interface{}{1}), [2]int{}, nil, "expected a list of 2 elements for a Go [2]int but got 1."},
+		{nil, []string{}, []string(nil), ""},
+		{NewMap(), map[string]int{}, map[string]int{}, ""},
+		{"a", map[string]int{}, nil, "expected a Go map[string]int but got \"a\"."},
+		{5, new(big.Int), big.NewInt(5), ""},
+		{NewHostObject(reflect.ValueOf(player)), &testPlayer{}, player, ""},
+		{NewHostObject(reflect.ValueOf(player)), testPlayer{}, *player, ""},
+		{NewHostObject(reflect.ValueOf(&testStats{})), &testPlayer{}, nil, "expected a Go *interp.testPlayer but got <testStats object>."},
+		{NewList([]interface{}{1}), (*interface{})(nil), nil, ""},
+	}
+
+	for _, test := range tests {
+		var typ reflect.Type = reflect.TypeOf(test.typ)
+		if pointer, ok := test.typ.(*interface{}); ok && pointer == nil {
+			typ = reflect.TypeOf(pointer).Elem()
+			test.want = []Value{1}
+		}
+		converted, err := goValue(test.value, typ)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("goValue(%s, %s) gave error %v, want %q", stringify(test.value), typ, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("goValue(%s, %s) gave error %v", stringify(test.value), typ, err)
+			continue
+		}
+		if !reflect.DeepEqual(converted.Interface(), test.want) {
+			t.Errorf("goValue(%s, %s) = %#v, want %#v", stringify(test.value), typ, converted.Interface(), test.want)
+		}
+	}
+}
+
+func TestReflectValue(t *testing.T) {
+	var tests = []struct {
+		value interface{}
+		// the value as printed by say, when the conversion succeeds
+		want string
+		err  string
+	}{
+		{int8(-3), "-3", ""},
+		{uint64(1 << 63), "9223372036854775808", ""},
+		{float32(0.5), "0.5", ""},
+		{big.NewRat(1, 4), "0.25", ""},
+		{big.NewRat(4, 2), "2", ""},
+		{[]int{1, 2}, "[1, 2]", ""},
+		{[]interface{}{"a", nil}, "[\"a\", empty]", ""},
+		{map[string]int{"b": 2, "a": 1}, "{\"a\": 1, \"b\": 2}", ""},
+		{map[int]bool{2: true, 1: false}, "{1: false, 2: true}", ""},
+		{(*testPlayer)(nil), "empty", ""},
+		{testStats{Wins: 1}, "<testStats object>", ""},
+		{make(chan int), "", "cannot convert a Go value of type chan int."},
+		{map[testStats]int{{}: 1}, "", "cannot convert a Go map with keys of type interp.testStats."},
+		{map[interface{}]int{nil: 1}, "", "cannot convert a Go map with the key"},
+		{[]chan int{nil}, "", "cannot convert a Go value of type chan int."},
+	}
+
+	for _, test := range tests {
+		converted, err := toValue(test.value)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("toValue(%#v) gave error %v, want %q", test.value, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("toValue(%#v) gave error %v", test.value, err)
+			continue
+		}
+		if display(converted) != test.want {
+			t.Errorf("toValue(%#v) = %s, want %s", test.value, display(converted), test.want)
+		}
+	}
+}
+
+func TestHostObject(t *testing.T) {
+	var tests = []struct {
+		src  string
+		want string
+		// kind and line of the error which stops the program
+		kind string
+		line int
+	}{
+		{src: `say p.Name, p.Score, p.Tags, p.Items, p.Stats.Wins, p.Friend;`, want: "ann 1 [\"a\"] {\"x\": 1} 0 empty\n"},
+		{src: "set p.Score to 5;\nincrement p.Score by 2;\nsay p.Score;", want: "7\n"},
+		{src: "set p.Stats.Wins to 3;\nsay p.Stats.Wins;", want: "3\n"},
+		{src: `say p.Heal(1.5), p.Check(2);`, want: "1.5 4\n"},
+		{src: "set q to p;\nsay p == q, p == p.Friend;", want: "true false\n"},
+		{src: "say 1;\nset p.Score to 1.5;", want: "1\n", kind: TYPE_ERROR, line: 2},
+		{src: "\n\nset p.Tags to [1];", kind: TYPE_ERROR, line: 3},
+		{src: `set p.Small to 300;`, kind: TYPE_ERROR, line: 1},
+		{src: "say p.Heal(\"a\");", kind: TYPE_ERROR, line: 1},
+		{src: "say 0;\nsay p.Check(-1);", want: "0\n", kind: RUNTIME_ERROR, line: 2},
+		{src: `say p.Scor;`, kind: NAME_ERROR, line: 1},
+		{src: `say p.hidden;`, kind: NAME_ERROR, line: 1},
+		{src: "try\n    set p.Score to \"x\"\ncatch e\n    say e.kind", want: "TypeError\n"},
+	}
+
+	for _, test := range tests {
+		var player *testPlayer = &testPlayer{Name: "ann", Score: 1, Tags: []string{"a"}, Items: map[string]int{"x": 1}}
+		var output bytes.Buffer
+		var itpr *Interpreter = NewInterpreter()
+		itpr.SetOutput(&output)
+		if err := itpr.Define("p", player); err != nil {
+			t.Fatalf("Define gave error %v", err)
+		}
+		itpr.SetIndentation(strings.Contains(test.src, "\n    "))
+
+		var err error = itpr.Run(test.src)
+		if output.String() != test.want {
+			t.Errorf("%q printed %q, want %q", test.src, output.String(), test.want)
+		}
+		if test.kind == "" {
+			if err != nil {
+				t.Errorf("%q gave error %v", test.src, err)
+			}
+			continue
+		}
+		runtimeErr, ok := err.(*RuntimeError)
+		if !ok {
+			t.Errorf("%q gave error %v, want a %s", test.src, err, test.kind)
+			continue
+		}
+		if runtimeErr.Kind != test.kind || runtimeErr.Line != test.line {
+			t.Errorf("%q gave %s on line %d, want %s on line %d", test.src, runtimeErr.Kind, runtimeErr.Line, test.kind, test.line)
+		}
+	}
+}
+
+func TestHostObjectWritesThrough(t *testing.T) {
+	var player *testPlayer = &testPlayer{}
+	var friend *testPlayer = &testPlayer{Name: "bob"}
+	var itpr *Interpreter = NewInterpreter()
+	itpr.SetOutput(&bytes.Buffer{})
+	itpr.Define("p", player)
+	itpr.Define("friend", friend)
+
+	var src string = `set p.Name to "ann"; set p.Tags to ["x", "y"]; set p.Items to {"k": 2}; set p.Pair to [3, 4]; set p.Friend to friend; set p.Count to 9;`
+	if err := itpr.Run(src); err != nil {
+		t.Fatalf("Run gave error %v", err)
+	}
+	var want testPlayer = testPlayer{Name: "ann", Tags: []string{"x", "y"}, Items: map[string]int{"k": 2}, Pair: [2]int{3, 4}, Friend: friend, Count: 9}
+	if !reflect.DeepEqual(*player, want) {
+		t.Errorf("player = %+v, want %+v", *player, want)
+	}
+
+	value, _ := itpr.Get("p")
+	if value != player {
+		t.Errorf("Get gave %#v, want the pointer which was defined", value)
+	}
+}
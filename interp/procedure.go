@@ -0,0 +1,125 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/idea456/psu-lang/ast"
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Callable is implemented by every runtime value which can be invoked with a call expression.
+*/
+type Callable interface {
+	arity() int
+	call(itpr *Interpreter, paren scanner.Token, arguments []interface{}) interface{}
+}
+
+/*
+ReturnValue is panicked by return statements to unwind through nested blocks and loops,
+and is recovered by the procedure which is currently being called.
+*/
+type ReturnValue struct {
+	value interface{}
+	// set for a return without a value, which hands back the outputs of an algorithm
+	bare bool
+}
+
+type Procedure struct {
+	declaration   *ast.ProcedureStmt
+	closure       *Environment
+	isInitializer bool
+	// outputs of an algorithm, which is nil for ordinary procedures
+	outputs []scanner.Token
+}
+
+func NewProcedure(declaration *ast.ProcedureStmt, closure *Environment, isInitializer bool) *Procedure {
+	var procedure Procedure = Procedure{}
+	procedure.declaration = declaration
+	procedure.closure = closure
+	procedure.isInitializer = isInitializer
+	return &procedure
+}
+
+func NewAlgorithm(algorithm *ast.AlgorithmStmt, closure *Environment) *Procedure {
+	var procedure *Procedure = NewProcedure(algorithm.Declaration, closure, false)
+	procedure.outputs = algorithm.Outputs
+	return procedure
+}
+
+/*
+bind returns a copy of the method whose closure defines 'this' as the given instance.
+*/
+func (procedure *Procedure) bind(instance *Instance) *Procedure {
+	var env *Environment = NewEnclosingEnv(procedure.closure)
+	env.Define("this", instance)
+	return NewProcedure(procedure.declaration, env, procedure.isInitializer)
+}
+
+func (procedure *Procedure) arity() int {
+	return len(procedure.declaration.Params)
+}
+
+func (procedure *Procedure) call(itpr *Interpreter, paren scanner.Token, arguments []interface{}) (result interface{}) {
+	itpr.enterCall(paren)
+	defer itpr.leaveCall()
+
+	// every call gets its own environment so that recursive calls do not share parameters
+	var env *Environment = NewEnclosingEnv(procedure.closure)
+	for i, param := range procedure.declaration.Params {
+		env.Define(param.Lexeme, arguments[i])
+	}
+	// outputs named like an input start out with the argument, which algorithms working in place rely on
+	for _, output := range procedure.outputs {
+		if _, isParam := env.values[output.Lexeme]; !isParam {
+			env.Define(output.Lexeme, nil)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			returned, ok := r.(*ReturnValue)
+			if !ok {
+				panic(r)
+			}
+			result = returned.value
+			if !returned.bare {
+				return
+			}
+		}
+		if procedure.outputs != nil {
+			result = procedure.outputValues(env)
+		}
+		// initializers always hand back the instance being created
+		if procedure.isInitializer {
+			result = procedure.closure.values["this"]
+		}
+	}()
+
+	itpr.executeBlock(procedure.declaration.Body, env)
+	return nil
+}
+
+/*
+outputValues collects the declared outputs of an algorithm, where several outputs are returned together.
+*/
+func (procedure *Procedure) outputValues(env *Environment) interface{} {
+	if len(procedure.outputs) == 0 {
+		return nil
+	}
+	if len(procedure.outputs) == 1 {
+		return env.values[procedure.outputs[0].Lexeme]
+	}
+	var values []interface{} = make([]interface{}, 0)
+	for _, output := range procedure.outputs {
+		values = append(values, env.values[output.Lexeme])
+	}
+	return NewList(values)
+}
+
+func (procedure *Procedure) String() string {
+	if procedure.outputs != nil {
+		return fmt.Sprintf("<algorithm %s>", procedure.declaration.Name.Lexeme)
+	}
+	return fmt.Sprintf("<procedure %s>", procedure.declaration.Name.Lexeme)
+}
@@ -0,0 +1,68 @@
+package interp
+
+import (
+	"os"
+
+	"github.com/idea456/psu-lang/ast"
+	"github.com/idea456/psu-lang/parser"
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Run runs source code on a new interpreter, returning a *scanner.SyntaxError when it cannot be parsed
+and a *RuntimeError when it stops on an error.
+*/
+func Run(src string) error {
+	return NewInterpreter().Run(src)
+}
+
+/*
+Run runs source code as if it was typed into the prompt, keeping the variables of earlier runs.
+Nothing runs unless the whole source can be parsed.
+*/
+func (itpr *Interpreter) Run(src string) error {
+	stmts, err := parse("", src, itpr.indentation)
+	if err != nil {
+		return err
+	}
+	itpr.SetPath("")
+	return itpr.Interpret(stmts)
+}
+
+/*
+RunFile runs a file, whose use statements are resolved next to it. Files ending in .pslg are always written with indentation.
+*/
+func (itpr *Interpreter) RunFile(path string) error {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	stmts, err := parse(path, string(bytes), itpr.indentation || UsesIndentation(path))
+	if err != nil {
+		return err
+	}
+	itpr.SetPath(path)
+	return itpr.Interpret(stmts)
+}
+
+/*
+SetIndentation switches Run and RunFile between brace-delimited blocks with semicolons and
+indentation-delimited blocks with newline-terminated statements.
+*/
+func (itpr *Interpreter) SetIndentation(enabled bool) {
+	itpr.indentation = enabled
+}
+
+/*
+parse scans and parses the source code of a file, where an empty path stands for the prompt.
+*/
+func parse(path string, src string, indentation bool) ([]ast.Statement, error) {
+	var s *scanner.Scanner = scanner.NewScanner(src)
+	s.SetIndentation(indentation)
+	s.SetPath(path)
+	tokens, err := s.Scan()
+	if err != nil {
+		return nil, err
+	}
+	return parser.NewParser(tokens).Parse()
+}
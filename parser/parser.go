@@ -0,0 +1,1461 @@
+/*
+Package parser builds the syntax tree of package ast from tokens, collecting every syntax error on the way.
+*/
+package parser
+
+import (
+	"github.com/idea456/psu-lang/ast"
+	diagnostics "github.com/idea456/psu-lang/error"
+	"github.com/idea456/psu-lang/scanner"
+)
+
+/*
+Stratified grammar:
+
+file -> declaration* EOF;
+
+Statements end with ";" or, when the scanner runs with indentation, with a NEWLINE.
+Blocks are either wrapped in braces or, when the scanner runs with indentation, indented below their header.
+declaration -> var_declaration | assume_declaration | procedure_declaration | algorithm_declaration | class_declaration | use_declaration | statement;
+var_declaration -> "set" (IDENTIFIER | call "." IDENTIFIER | call "[" expression "]") ("to" (expression | incr_decr))? ";"
+assume_declaration -> "assume" IDENTIFIER "to" expression ";"
+procedure_declaration -> "procedure" IDENTIFIER "(" parameters? ")" procedure_body;
+algorithm_declaration -> "algorithm" IDENTIFIER "(" parameters? ")" ("outputs" IDENTIFIER ("," IDENTIFIER)*)? procedure_body;
+use_declaration -> "use" (IDENTIFIER ("," IDENTIFIER)* "from")? STRING ("as" IDENTIFIER)? ";"
+parameters -> IDENTIFIER ("," IDENTIFIER)*;
+procedure_body -> block_stmt | indent_block;
+class_declaration -> "class" IDENTIFIER ("extends" IDENTIFIER)? ("{" procedure_declaration* "}" | NEWLINE INDENT procedure_declaration* DEDENT);
+statement -> say_stmt | expr_stmt | incr_decr_stmt | if_stmt | while_stmt | for_stmt | delete_stmt | return_stmt | break_stmt | continue_stmt | try_stmt | raise_stmt | block_stmt;
+say_stmt -> "say" expression ("," expression)* ("separated" "by" expression)? ";"
+expr_stmt -> expression ";"
+incr_decr_stmt -> ("increment" | "decrement") (IDENTIFIER | call "." IDENTIFIER | call "[" expression "]") "by" expression;
+if_stmt -> "if" expression "then" body ("else" body)?;
+while_stmt -> "while" expression "do" body;
+for_stmt -> "for" IDENTIFIER "from" expression "to" expression ("by" expression)? "do" body | for_each_stmt;
+for_each_stmt -> "for" "each" IDENTIFIER "in" expression "do" body;
+delete_stmt -> "delete" call "[" expression "]" ";"
+return_stmt -> "return" expression? ";"
+break_stmt -> ("break" | "exit" "loop") ";"
+continue_stmt -> ("continue" | "skip") ";"
+try_stmt -> "try" body ("catch" IDENTIFIER? body)? ("finally" body)?;
+raise_stmt -> "raise" expression ("as" expression)? ";"
+body -> indent_block | statement;
+block_stmt -> "{" declaration* "}"
+indent_block -> NEWLINE INDENT declaration* DEDENT
+
+expression -> equality | logical_or;
+logical_or -> logical_and ("or" logical_and)*;
+logical_and -> equality ("and" equality)*;
+equality -> comparison (("==" | "!=") comparison)*;
+comparison -> range ((">" | ">=" | "<" | "<=" | "has") range)*;
+range -> term (".." term)?;
+term -> factor (("+" | "-") factor)*;
+factor -> unary (("*" | "/" | "%" | "div") unary)*
+unary -> ("-" | "!") unary | ("length" | "keys") "of" unary | call;
+call -> primary ("(" arguments? ")" | "." IDENTIFIER | "[" expression "]")*;
+arguments -> expression ("," expression)*;
+primary -> NUMBER | STRING | interpolation | IDENTIFIER | "true" | "false" | "empty" | "this" | "parent" "." IDENTIFIER | "(" expression ")" | list | map;
+list -> "[" (expression ("," expression)*)? "]";
+map -> "{" (expression ":" expression ("," expression ":" expression)*)? "}";
+interpolation -> (INTERPOLATION expression (":" STRING)? INTERPOLATION_END)+ STRING;
+*/
+
+type classType int
+
+const (
+	NO_CLASS classType = iota
+	IN_CLASS
+	IN_SUBCLASS
+)
+
+type Parser struct {
+	tokens  []scanner.Token
+	current int
+	// number of procedure bodies currently being parsed, used to reject stray returns
+	procedureDepth int
+	// number of loop bodies currently being parsed within the current procedure, used to reject stray breaks
+	loopDepth int
+	// kind of class body currently being parsed, used to reject stray 'this' and 'parent'
+	currentClass classType
+	// names declared with 'assume' in each block scope which is currently open
+	constants []map[string]bool
+	// syntax errors found so far, parsing carries on after each of them
+	errors []diagnostics.Diagnostic
+}
+
+func NewParser(tokens []scanner.Token) *Parser {
+	var parser Parser = Parser{}
+	parser.tokens = tokens
+	parser.current = 0
+	parser.constants = []map[string]bool{make(map[string]bool)}
+	return &parser
+}
+
+/*
+Parse parses the whole file, returning the statements which could be parsed. Every syntax error found
+is returned together in a *SyntaxError.
+*/
+func (p *Parser) Parse() ([]ast.Statement, error) {
+	var statements []ast.Statement = make([]ast.Statement, 0)
+	p.errors = make([]diagnostics.Diagnostic, 0)
+	// var expr Expression = p.expression()
+
+	for p.skipNewlines(); p.peek().Type != scanner.EOF; p.skipNewlines() {
+		if stmt := p.synchronizedDeclaration(); stmt != nil {
+			statements = append(statements, stmt)
+		}
+	}
+
+	if len(p.errors) > 0 {
+		return statements, scanner.NewSyntaxError(p.tokens[len(p.tokens)-1].Span.Source, p.errors)
+	}
+	return statements, nil
+}
+
+/*
+synchronizedDeclaration parses a declaration, and on a syntax error records it and skips to the start of
+the next statement, so that parsing carries on. Broken statements are left out and nil is returned instead.
+*/
+func (p *Parser) synchronizedDeclaration() (stmt ast.Statement) {
+	var start int = p.current
+	defer func() {
+		// exit panic mode and synchronize to the nearest starting statement keyword
+		if r := recover(); r != nil {
+			d, ok := r.(diagnostics.Diagnostic)
+			if !ok {
+				panic(r)
+			}
+			p.errors = append(p.errors, d)
+			p.synchronize()
+			// always move on, so that a token which cannot start any statement is not reported forever
+			if p.current == start && !p.end() {
+				p.next()
+			}
+			stmt = nil
+		}
+	}()
+	return p.declaration()
+}
+
+/*
+declaration -> var_declaration | assume_declaration | procedure_declaration | algorithm_declaration | class_declaration | use_declaration | statement;
+*/
+func (p *Parser) declaration() ast.Statement {
+	var start scanner.Token = p.peek()
+	// every statement must have terminating semicolon or newline
+	defer func() {
+		// let errors raised while parsing the statement through instead of reporting a missing semicolon
+		if r := recover(); r != nil {
+			panic(r)
+		}
+		// statements ending with a block such as procedures may omit the semicolon
+		if p.previous().Type == scanner.RIGHT_BRACE || p.previous().Type == scanner.DEDENT {
+			p.match(scanner.SEMICOLON)
+			p.match(scanner.NEWLINE)
+			return
+		}
+		if p.match(scanner.SEMICOLON) {
+			p.match(scanner.NEWLINE)
+			return
+		}
+		if !p.end() && !p.match(scanner.NEWLINE) {
+			// if p.previous().tokenType == SEMICOLON && p.peek().tokenType == RIGHT_BRACE {
+			// 	return
+			// }
+			if p.previous().Type == scanner.SEMICOLON && p.match(scanner.RIGHT_BRACE) {
+				return
+			}
+			// a statement starting with a misspelled keyword is read as an expression, which ends right after it
+			var d diagnostics.Diagnostic = scanner.NewDiagnostic(scanner.SYNTAX_ERROR, p.previous().Span, p.previous().Lexeme, "expected semicolon after statement!")
+			if start.Type == scanner.IDENTIFIER {
+				d.Hints = diagnostics.DidYouMean(start.Lexeme, scanner.KeywordNames())
+			}
+			panic(d)
+		}
+	}()
+
+	if p.match(scanner.SET) {
+		return p.var_declaration()
+	}
+	if p.match(scanner.ASSUME) {
+		return p.assume_declaration()
+	}
+	if p.match(scanner.PROCEDURE) {
+		return p.procedure_declaration()
+	}
+	if p.match(scanner.ALGORITHM) {
+		return p.algorithm_declaration()
+	}
+	if p.match(scanner.CLASS) {
+		return p.class_declaration()
+	}
+	if p.match(scanner.USE) {
+		return p.use_declaration()
+	}
+
+	return p.statement()
+}
+
+/*
+var_declaration -> "set" (IDENTIFIER | call "." IDENTIFIER | call "[" expression "]") ("to" expression)? ";"
+*/
+func (p *Parser) var_declaration() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	var start scanner.Token = p.peek()
+	var identifier scanner.Token
+
+	switch target := p.call().(type) {
+	case *ast.Variable:
+		identifier = target.Name
+		p.checkNotConstant(identifier)
+	case *ast.Get:
+		// fields of instances are assigned through a set expression instead
+		p.consume(scanner.TO, "expected 'to' after field name.")
+		var value ast.Expression = p.expression()
+		return &ast.ExprStmt{
+			Span: p.spanFrom(keyword),
+			Expression: &ast.Set{
+				Span:   target.Location().To(value.Location()),
+				Object: target.Object,
+				Name:   target.Name,
+				Value:  value,
+			},
+		}
+	case *ast.Index:
+		p.consume(scanner.TO, "expected 'to' after index.")
+		var value ast.Expression = p.expression()
+		return &ast.ExprStmt{
+			Span: p.spanFrom(keyword),
+			Expression: &ast.SetIndex{
+				Span:    target.Location().To(value.Location()),
+				Object:  target.Object,
+				Bracket: target.Bracket,
+				Index:   target.Index,
+				Value:   value,
+			},
+		}
+	default:
+		p.error(start, "invalid assignment target.")
+	}
+
+	var expr ast.Expression = nil
+	for p.match(scanner.TO) {
+		expr = p.expression()
+	}
+
+	return &ast.VariableStmt{Span: p.spanFrom(keyword), Name: identifier, Initializer: expr, Doc: keyword.Doc}
+}
+
+/*
+assume_declaration -> "assume" IDENTIFIER "to" expression ";"
+*/
+func (p *Parser) assume_declaration() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	var name scanner.Token = p.peekName()
+	p.consume(scanner.IDENTIFIER, "expected constant name after 'assume'.")
+	p.checkNotConstant(name)
+	p.consume(scanner.TO, "expected 'to' after constant name.")
+
+	p.constants[len(p.constants)-1][name.Lexeme] = true
+	var initializer ast.Expression = p.expression()
+	return &ast.AssumeStmt{
+		Span:        p.spanFrom(keyword),
+		Name:        name,
+		Initializer: initializer,
+		Doc:         keyword.Doc,
+	}
+}
+
+/*
+procedure_declaration -> "procedure" IDENTIFIER "(" parameters? ")" procedure_body;
+*/
+func (p *Parser) procedure_declaration() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	var name scanner.Token = p.peekName()
+	p.consume(scanner.IDENTIFIER, "expected procedure name after 'procedure'.")
+	var params []scanner.Token = p.parameters()
+	var body []ast.Statement = p.procedure_body()
+
+	return &ast.ProcedureStmt{
+		Span:   p.spanFrom(keyword),
+		Name:   name,
+		Params: params,
+		Body:   body,
+		Doc:    keyword.Doc,
+	}
+}
+
+/*
+algorithm_declaration -> "algorithm" IDENTIFIER "(" parameters? ")" ("outputs" IDENTIFIER ("," IDENTIFIER)*)? procedure_body;
+*/
+func (p *Parser) algorithm_declaration() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	var name scanner.Token = p.peekName()
+	p.consume(scanner.IDENTIFIER, "expected algorithm name after 'algorithm'.")
+	var params []scanner.Token = p.parameters()
+
+	var outputs []scanner.Token = make([]scanner.Token, 0)
+	if p.match(scanner.OUTPUTS) {
+		for {
+			var output scanner.Token = p.peekName()
+			p.consume(scanner.IDENTIFIER, "expected output name.")
+			outputs = append(outputs, output)
+			if !p.match(scanner.COMMA) {
+				break
+			}
+		}
+	}
+
+	var body []ast.Statement = p.procedure_body()
+	return &ast.AlgorithmStmt{
+		Span: p.spanFrom(keyword),
+		Declaration: &ast.ProcedureStmt{
+			Span:   p.spanFrom(keyword),
+			Name:   name,
+			Params: params,
+			Body:   body,
+			Doc:    keyword.Doc,
+		},
+		Outputs: outputs,
+	}
+}
+
+/*
+use_declaration -> "use" (IDENTIFIER ("," IDENTIFIER)* "from")? STRING ("as" IDENTIFIER)? ";"
+*/
+func (p *Parser) use_declaration() ast.Statement {
+	var stmt *ast.UseStmt = &ast.UseStmt{
+		Keyword: p.previous(),
+		Names:   make([]scanner.Token, 0),
+	}
+
+	if p.peekName().Type == scanner.IDENTIFIER {
+		for {
+			var name scanner.Token = p.peekName()
+			p.consume(scanner.IDENTIFIER, "expected name to use from module.")
+			stmt.Names = append(stmt.Names, name)
+			if !p.match(scanner.COMMA) {
+				break
+			}
+		}
+		p.consume(scanner.FROM, "expected 'from' after names to use.")
+	}
+
+	stmt.Path = p.peek()
+	p.consume(scanner.STRING, "expected module path after 'use'.")
+
+	if p.match(scanner.AS) {
+		if len(stmt.Names) > 0 {
+			p.error(p.previous(), "cannot name a module when using names from it.")
+		}
+		var alias scanner.Token = p.peekName()
+		p.consume(scanner.IDENTIFIER, "expected namespace after 'as'.")
+		stmt.Alias = &alias
+	}
+	stmt.Span = p.spanFrom(stmt.Keyword)
+	return stmt
+}
+
+/*
+parameters -> IDENTIFIER ("," IDENTIFIER)*;
+*/
+func (p *Parser) parameters() []scanner.Token {
+	p.consume(scanner.LEFT_PAREN, "expected '(' before parameters.")
+
+	var params []scanner.Token = make([]scanner.Token, 0)
+	if p.peek().Type != scanner.RIGHT_PAREN {
+		for {
+			var param scanner.Token = p.peekName()
+			p.consume(scanner.IDENTIFIER, "expected parameter name.")
+			params = append(params, param)
+			if !p.match(scanner.COMMA) {
+				break
+			}
+		}
+	}
+	p.consume(scanner.RIGHT_PAREN, "expected ')' after parameters.")
+	return params
+}
+
+/*
+procedure_body -> block_stmt | indent_block;
+*/
+func (p *Parser) procedure_body() []ast.Statement {
+	// loops around a procedure declaration cannot be left from within the procedure
+	var enclosingLoops int = p.loopDepth
+	p.procedureDepth += 1
+	p.loopDepth = 0
+	defer func() {
+		p.procedureDepth -= 1
+		p.loopDepth = enclosingLoops
+	}()
+
+	var body *ast.BlockStmt
+	if p.matchIndent() {
+		body = p.indent_block().(*ast.BlockStmt)
+	} else {
+		p.consume(scanner.LEFT_BRACE, "expected '{' before procedure body.")
+		body = p.block_stmt().(*ast.BlockStmt)
+	}
+	return body.Statements
+}
+
+/*
+class_declaration -> "class" IDENTIFIER ("extends" IDENTIFIER)? ("{" procedure_declaration* "}" | NEWLINE INDENT procedure_declaration* DEDENT);
+*/
+func (p *Parser) class_declaration() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	var name scanner.Token = p.peekName()
+	p.consume(scanner.IDENTIFIER, "expected class name after 'class'.")
+
+	var enclosingClass classType = p.currentClass
+	p.currentClass = IN_CLASS
+	defer func() {
+		p.currentClass = enclosingClass
+	}()
+
+	var superclass *ast.Variable = nil
+	if p.match(scanner.EXTENDS) {
+		var superName scanner.Token = p.peekName()
+		p.consume(scanner.IDENTIFIER, "expected parent class name after 'extends'.")
+		if superName.Lexeme == name.Lexeme {
+			p.error(superName, "a class cannot extend itself.")
+		}
+		superclass = &ast.Variable{
+			Span: superName.Span,
+			Name: superName,
+		}
+		p.currentClass = IN_SUBCLASS
+	}
+
+	var closing scanner.TokenType = scanner.DEDENT
+	if !p.matchIndent() {
+		p.consume(scanner.LEFT_BRACE, "expected '{' before class body.")
+		closing = scanner.RIGHT_BRACE
+	}
+	var methods []*ast.ProcedureStmt = make([]*ast.ProcedureStmt, 0)
+	for p.skipNewlines(); !p.match(closing); p.skipNewlines() {
+		if p.end() {
+			p.error(p.peek(), "expect end of class body!")
+		}
+		p.consume(scanner.PROCEDURE, "class bodies can only contain procedures.")
+		methods = append(methods, p.procedure_declaration().(*ast.ProcedureStmt))
+		p.match(scanner.SEMICOLON)
+	}
+
+	return &ast.ClassStmt{
+		Span:       p.spanFrom(keyword),
+		Name:       name,
+		Superclass: superclass,
+		Methods:    methods,
+		Doc:        keyword.Doc,
+	}
+}
+
+/*
+statement -> say_stmt | expr_stmt | incr_decr_stmt | if_stmt | while_stmt | for_stmt | delete_stmt | return_stmt | break_stmt | continue_stmt | try_stmt | raise_stmt | block;
+*/
+func (p *Parser) statement() ast.Statement {
+	// indented blocks only follow the line break after the header of a statement
+	if p.peek().Type == scanner.INDENT {
+		p.error(p.peek(), "unexpected indent, only the lines of a block go further in than the line before.")
+	}
+	if p.match(scanner.SAY) {
+		return p.say_stmt()
+	}
+	if p.match(scanner.LEFT_BRACE) {
+		return p.block_stmt()
+	}
+	if p.match(scanner.INCREMENT, scanner.DECREMENT) {
+		return p.incr_decr_stmt()
+	}
+	if p.match(scanner.IF) {
+		return p.if_stmt()
+	}
+	if p.match(scanner.WHILE) {
+		return p.while_stmt()
+	}
+	if p.match(scanner.FOR) {
+		return p.for_stmt()
+	}
+	if p.matchKeyword(scanner.DELETE, scanner.IDENTIFIER, scanner.THIS) {
+		return p.delete_stmt()
+	}
+	if p.match(scanner.RETURN) {
+		return p.return_stmt()
+	}
+	if p.match(scanner.BREAK) || p.matchKeyword(scanner.EXIT, scanner.IDENTIFIER) {
+		return p.break_stmt()
+	}
+	if p.match(scanner.CONTINUE) || p.matchKeyword(scanner.SKIP, scanner.SEMICOLON, scanner.NEWLINE, scanner.RIGHT_BRACE, scanner.DEDENT, scanner.EOF) {
+		return p.continue_stmt()
+	}
+	if p.match(scanner.TRY) {
+		return p.try_stmt()
+	}
+	if p.match(scanner.RAISE) {
+		return p.raise_stmt()
+	}
+	return p.expr_stmt()
+
+}
+
+/*
+say_stmt -> "say" expression ("," expression)* ("separated" "by" expression)? ";"
+*/
+func (p *Parser) say_stmt() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	var expressions []ast.Expression = []ast.Expression{p.expression()}
+	for p.match(scanner.COMMA) {
+		expressions = append(expressions, p.expression())
+	}
+
+	var separator ast.Expression
+	if p.match(scanner.SEPARATED) {
+		p.consume(scanner.BY, "expected 'by' after 'separated'.")
+		separator = p.expression()
+	}
+
+	return &ast.SayStmt{
+		Span:        p.spanFrom(keyword),
+		Keyword:     keyword,
+		Expressions: expressions,
+		Separator:   separator,
+	}
+}
+
+/*
+expr_stmt -> expression ";"
+*/
+func (p *Parser) expr_stmt() ast.Statement {
+	var expr ast.Expression = p.expression()
+	return &ast.ExprStmt{
+		Span:       expr.Location(),
+		Expression: expr,
+	}
+}
+
+/*
+incr_decr_stmt -> ("increment" | "decrement") (IDENTIFIER | call "." IDENTIFIER | call "[" expression "]") "by" expression;
+*/
+func (p *Parser) incr_decr_stmt() ast.Statement {
+	var operator scanner.Token = p.previous()
+	var start scanner.Token = p.peek()
+
+	var target ast.Expression = p.call()
+	switch target := target.(type) {
+	case *ast.Variable:
+		p.checkNotConstant(target.Name)
+	case *ast.Get, *ast.Index:
+	default:
+		p.error(start, "invalid increment/decrement target.")
+	}
+
+	if p.match(scanner.BY) {
+		var right ast.Expression = p.expression()
+		return &ast.IncrDecrStmt{
+			Span:     p.spanFrom(operator),
+			Target:   target,
+			Operator: operator,
+			Right:    right,
+		}
+	} else {
+		p.error(p.peek(), "increment/decrement statements must be followed with 'by'.")
+		return nil
+	}
+}
+
+func (p *Parser) if_stmt() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	// p.consume(LEFT_PAREN, "expected '(' in if statement")
+	var expr ast.Expression = p.expression()
+	// p.consume(RIGHT_PAREN, "expected ')' after if statement")
+	p.consume(scanner.THEN, "if statements are followed by 'then'")
+
+	var thenBranch ast.Statement = p.body()
+	var elseBranch ast.Statement
+	p.skipNewlineBefore(scanner.ELSE)
+	if p.match(scanner.ELSE) {
+		elseBranch = p.body()
+	}
+
+	return &ast.IfStmt{
+		Span:       p.spanFrom(keyword),
+		Expression: expr,
+		ThenBranch: thenBranch,
+		ElseBranch: elseBranch,
+	}
+}
+
+/*
+while_stmt -> "while" expression "do" body;
+*/
+func (p *Parser) while_stmt() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	var expr ast.Expression = p.expression()
+
+	if !p.match(scanner.DO) {
+		var token scanner.Token = p.peek()
+		p.error(token, "expected 'do' after while statement.")
+	}
+
+	// if !p.match(LEFT_BRACE) {
+	// 	var token Token = p.peek()
+	// 	RuntimeError(token.line, token.lexeme, "expected '{' in while statement.")
+	// }
+
+	// for !p.match(RIGHT_BRACE) {
+	// 	body := &
+	// }
+	// body := p.statement()
+
+	// if p.end() || !p.match(RIGHT_BRACE) {
+	// 	var token Token = p.peek()
+	// 	RuntimeError(token.line, token.lexeme, "expected '}' in while statement.")
+	// }
+
+	var body ast.Statement = p.loop_body()
+	return &ast.WhileStmt{
+		Span:      p.spanFrom(keyword),
+		Condition: expr,
+		Body:      body,
+	}
+}
+
+/*
+for_stmt -> "for" IDENTIFIER "from" expression "to" expression ("by" expression)? "do" body | for_each_stmt;
+*/
+func (p *Parser) for_stmt() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	// "for each from 1 to n" counts with a loop variable named each
+	if p.peekNext().Type != scanner.FROM && p.match(scanner.EACH) {
+		return p.for_each_stmt(keyword)
+	}
+	var variable scanner.Token = p.peekName()
+	p.consume(scanner.IDENTIFIER, "expected loop variable after 'for'.")
+	p.consume(scanner.FROM, "expected 'from' after for loop variable.")
+	var start ast.Expression = p.expression()
+	p.consume(scanner.TO, "expected 'to' after for loop start.")
+	var end ast.Expression = p.expression()
+
+	var step ast.Expression = nil
+	if p.match(scanner.BY) {
+		step = p.expression()
+	}
+	p.consume(scanner.DO, "expected 'do' after for loop range.")
+
+	var body ast.Statement = p.loop_body()
+	return &ast.ForStmt{
+		Span:     p.spanFrom(keyword),
+		Variable: variable,
+		Start:    start,
+		End:      end,
+		Step:     step,
+		Body:     body,
+	}
+}
+
+/*
+for_each_stmt -> "for" "each" IDENTIFIER "in" expression "do" body;
+*/
+func (p *Parser) for_each_stmt(keyword scanner.Token) ast.Statement {
+	var variable scanner.Token = p.peekName()
+	p.consume(scanner.IDENTIFIER, "expected loop variable after 'for each'.")
+	p.consume(scanner.IN, "expected 'in' after for each loop variable.")
+	var collection ast.Expression = p.expression()
+	p.consume(scanner.DO, "expected 'do' after for each collection.")
+
+	var body ast.Statement = p.loop_body()
+	return &ast.ForEachStmt{
+		Span:       p.spanFrom(keyword),
+		Variable:   variable,
+		Collection: collection,
+		Body:       body,
+	}
+}
+
+/*
+delete_stmt -> "delete" call "[" expression "]" ";"
+*/
+func (p *Parser) delete_stmt() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	target, ok := p.call().(*ast.Index)
+	if !ok {
+		p.error(keyword, "can only delete entries of a map.")
+	}
+	return &ast.DeleteStmt{
+		Span:    p.spanFrom(keyword),
+		Keyword: keyword,
+		Target:  target,
+	}
+}
+
+/*
+return_stmt -> "return" expression? ";"
+*/
+func (p *Parser) return_stmt() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	if p.procedureDepth == 0 {
+		p.error(keyword, "cannot return outside of a procedure.")
+	}
+
+	var value ast.Expression = nil
+	if p.peek().Type != scanner.SEMICOLON && p.peek().Type != scanner.NEWLINE && p.peek().Type != scanner.RIGHT_BRACE && !p.end() {
+		value = p.expression()
+	}
+
+	return &ast.ReturnStmt{
+		Span:    p.spanFrom(keyword),
+		Keyword: keyword,
+		Value:   value,
+	}
+}
+
+/*
+break_stmt -> ("break" | "exit" "loop") ";"
+*/
+func (p *Parser) break_stmt() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	if keyword.Type == scanner.EXIT {
+		if p.peek().Type != scanner.IDENTIFIER || p.peek().Lexeme != "loop" {
+			p.error(keyword, "expected 'loop' after 'exit'.")
+		}
+		p.next()
+	}
+	if p.loopDepth == 0 {
+		p.error(keyword, "cannot break outside of a loop.")
+	}
+	return &ast.BreakStmt{
+		Span:    p.spanFrom(keyword),
+		Keyword: keyword,
+	}
+}
+
+/*
+continue_stmt -> ("continue" | "skip") ";"
+*/
+func (p *Parser) continue_stmt() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	if p.loopDepth == 0 {
+		p.error(keyword, "cannot continue outside of a loop.")
+	}
+	return &ast.ContinueStmt{
+		Span:    p.spanFrom(keyword),
+		Keyword: keyword,
+	}
+}
+
+/*
+try_stmt -> "try" body ("catch" IDENTIFIER? body)? ("finally" body)?;
+*/
+func (p *Parser) try_stmt() ast.Statement {
+	var keyword scanner.Token = p.previous()
+	var stmt *ast.TryStmt = &ast.TryStmt{
+		TryBranch: p.body(),
+	}
+
+	p.skipNewlineBefore(scanner.CATCH)
+	if p.match(scanner.CATCH) {
+		if p.peekName().Type == scanner.IDENTIFIER {
+			var name scanner.Token = p.next()
+			stmt.Name = &name
+		}
+		stmt.CatchBranch = p.body()
+	}
+
+	p.skipNewlineBefore(scanner.FINALLY)
+	if p.match(scanner.FINALLY) {
+		stmt.FinallyBranch = p.body()
+	}
+
+	if stmt.CatchBranch == nil && stmt.FinallyBranch == nil {
+		p.error(keyword, "expected 'catch' or 'finally' after try statement.")
+	}
+	stmt.Span = p.spanFrom(keyword)
+	return stmt
+}
+
+/*
+raise_stmt -> "raise" expression ("as" expression)? ";"
+*/
+func (p *Parser) raise_stmt() ast.Statement {
+	var stmt *ast.RaiseStmt = &ast.RaiseStmt{
+		Keyword: p.previous(),
+		Value:   p.expression(),
+	}
+	if p.match(scanner.AS) {
+		stmt.Kind = p.expression()
+	}
+	stmt.Span = p.spanFrom(stmt.Keyword)
+	return stmt
+}
+
+/*
+block -> "{" declaration* "}"
+*/
+func (p *Parser) block_stmt() ast.Statement {
+	var brace scanner.Token = p.previous()
+	var statements []ast.Statement = make([]ast.Statement, 0)
+
+	p.constants = append(p.constants, make(map[string]bool))
+	defer func() {
+		p.constants = p.constants[:len(p.constants)-1]
+	}()
+
+	for p.skipNewlines(); !(p.match(scanner.RIGHT_BRACE)) && p.peek().Type != scanner.EOF; p.skipNewlines() {
+		if stmt := p.synchronizedDeclaration(); stmt != nil {
+			statements = append(statements, stmt)
+		}
+	}
+
+	// if p.peek().tokenType == EOF && p.previous().tokenType != RIGHT_BRACE {
+	// 	var token Token = p.peek()
+	// 	RuntimeError(token.line, token.lexeme, "expect closing braces in block statement!")
+	// }
+	// !p.match(RIGHT_BRACE)
+	if p.previous().Type != scanner.RIGHT_BRACE {
+		var token scanner.Token = p.peek()
+		p.error(token, "expect closing braces in block statement!")
+	}
+	return &ast.BlockStmt{
+		Span:       p.spanFrom(brace),
+		Statements: statements,
+	}
+}
+
+/*
+loop_body parses the body of a loop, within which break and continue are allowed.
+*/
+func (p *Parser) loop_body() ast.Statement {
+	p.loopDepth += 1
+	defer func() {
+		p.loopDepth -= 1
+	}()
+	return p.body()
+}
+
+/*
+body -> indent_block | statement;
+*/
+func (p *Parser) body() ast.Statement {
+	if p.matchIndent() {
+		return p.indent_block()
+	}
+	return p.statement()
+}
+
+/*
+indent_block -> NEWLINE INDENT declaration* DEDENT
+*/
+func (p *Parser) indent_block() ast.Statement {
+	var start scanner.Token = p.peek()
+	var statements []ast.Statement = make([]ast.Statement, 0)
+
+	p.constants = append(p.constants, make(map[string]bool))
+	defer func() {
+		p.constants = p.constants[:len(p.constants)-1]
+	}()
+
+	for p.skipNewlines(); !p.match(scanner.DEDENT); p.skipNewlines() {
+		if p.end() {
+			p.error(p.peek(), "expect end of indented block!")
+		}
+		if stmt := p.synchronizedDeclaration(); stmt != nil {
+			statements = append(statements, stmt)
+		}
+	}
+	return &ast.BlockStmt{
+		Span:       p.spanFrom(start),
+		Statements: statements,
+	}
+}
+
+func (p *Parser) expression() ast.Expression {
+	// if p.match(INCREMENT, DECREMENT) {
+	// 	if p.match(BY) {
+
+	// 	} else {
+	// 		panic("Increment/decrement must follow 'by' keyword!")
+	// 	}
+	// }
+
+	// return p.equality()
+	return p.logical_or()
+}
+
+/*
+logical_or -> logical_and ("or" logical_and)*;
+logical_and -> equality ("and" equality)*;
+*/
+func (p *Parser) logical_or() ast.Expression {
+	var expr ast.Expression = p.logical_and()
+
+	for p.match(scanner.OR) {
+		var operator scanner.Token = p.previous()
+		var right ast.Expression = p.logical_and()
+		expr = &ast.Logical{
+			Span:     expr.Location().To(right.Location()),
+			Left:     expr,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+func (p *Parser) logical_and() ast.Expression {
+	var expr ast.Expression = p.equality()
+
+	for p.match(scanner.AND) {
+		var operator scanner.Token = p.previous()
+		var right ast.Expression = p.equality()
+		expr = &ast.Logical{
+			Span:     expr.Location().To(right.Location()),
+			Left:     expr,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+func (p *Parser) equality() ast.Expression {
+	var expr ast.Expression = p.comparison()
+
+	for p.match(scanner.EQUAL_EQUAL, scanner.NOT_EQUAL) {
+		var operator scanner.Token = p.previous()
+		var right ast.Expression = p.comparison()
+		expr = &ast.Binary{
+			Span:     expr.Location().To(right.Location()),
+			Left:     expr,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+/*
+comparison -> range ((">" | ">=" | "<" | "<=" | "has") range)*;
+*/
+func (p *Parser) comparison() ast.Expression {
+	var expr ast.Expression = p.range_expr()
+
+	for p.match(scanner.LESS, scanner.LESS_EQUAL, scanner.GREATER, scanner.GREATER_EQUAL, scanner.HAS) {
+		var operator scanner.Token = p.previous()
+		var right ast.Expression = p.range_expr()
+		expr = &ast.Binary{
+			Span:     expr.Location().To(right.Location()),
+			Left:     expr,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+/*
+range -> term (".." term)?;
+*/
+func (p *Parser) range_expr() ast.Expression {
+	var expr ast.Expression = p.term()
+
+	if p.match(scanner.DOT_DOT) {
+		var operator scanner.Token = p.previous()
+		var end ast.Expression = p.term()
+		return &ast.RangeExpr{
+			Span:     expr.Location().To(end.Location()),
+			Start:    expr,
+			Operator: operator,
+			End:      end,
+		}
+	}
+	return expr
+}
+
+/*
+term -> factor (("+" | "-") factor)*;
+*/
+func (p *Parser) term() ast.Expression {
+	var expr ast.Expression = p.factor()
+
+	for p.match(scanner.PLUS, scanner.MINUS) {
+		var operator scanner.Token = p.previous()
+		var right ast.Expression = p.factor()
+		expr = &ast.Binary{
+			Span:     expr.Location().To(right.Location()),
+			Left:     expr,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+func (p *Parser) factor() ast.Expression {
+	var expr ast.Expression = p.unary()
+
+	for p.match(scanner.STAR, scanner.SLASH, scanner.MODULUS, scanner.DIV) {
+		var operator scanner.Token = p.previous()
+		var right ast.Expression = p.unary()
+		expr = &ast.Binary{
+			Span:     expr.Location().To(right.Location()),
+			Left:     expr,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+/*
+unary -> ("!" | "-") unary | ("length" | "keys") "of" unary | call;
+*/
+func (p *Parser) unary() ast.Expression {
+	if p.matchKeyword(scanner.LENGTH, scanner.OF) {
+		var keyword scanner.Token = p.previous()
+		p.consume(scanner.OF, "expected 'of' after 'length'.")
+		var object ast.Expression = p.unary()
+		return &ast.Length{
+			Span:    p.spanFrom(keyword),
+			Keyword: keyword,
+			Object:  object,
+		}
+	}
+	if p.matchKeyword(scanner.KEYS, scanner.OF) {
+		var keyword scanner.Token = p.previous()
+		p.consume(scanner.OF, "expected 'of' after 'keys'.")
+		var object ast.Expression = p.unary()
+		return &ast.Keys{
+			Span:    p.spanFrom(keyword),
+			Keyword: keyword,
+			Object:  object,
+		}
+	}
+	for p.match(scanner.NOT, scanner.MINUS) {
+		var operator scanner.Token = p.previous()
+		var right ast.Expression = p.unary()
+		return &ast.Unary{
+			Span:     p.spanFrom(operator),
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return p.call()
+}
+
+/*
+call -> primary ("(" arguments? ")" | "." IDENTIFIER | "[" expression "]")*;
+arguments -> expression ("," expression)*;
+*/
+func (p *Parser) call() ast.Expression {
+	var expr ast.Expression = p.primary()
+
+	for p.peek().Type == scanner.LEFT_PAREN || p.peek().Type == scanner.DOT || p.peek().Type == scanner.LEFT_BRACKET {
+		if p.match(scanner.LEFT_BRACKET) {
+			var bracket scanner.Token = p.previous()
+			var index ast.Expression = p.expression()
+			p.consume(scanner.RIGHT_BRACKET, "expected ']' after index.")
+			expr = &ast.Index{
+				Span:    expr.Location().To(p.previous().Span),
+				Object:  expr,
+				Bracket: bracket,
+				Index:   index,
+			}
+			continue
+		}
+		if p.match(scanner.DOT) {
+			var name scanner.Token = p.peekName()
+			p.consume(scanner.IDENTIFIER, "expected property name after '.'.")
+			expr = &ast.Get{
+				Span:   expr.Location().To(name.Span),
+				Object: expr,
+				Name:   name,
+			}
+			continue
+		}
+		p.next()
+
+		var arguments []ast.Expression = make([]ast.Expression, 0)
+		if p.peek().Type != scanner.RIGHT_PAREN {
+			for {
+				arguments = append(arguments, p.expression())
+				if !p.match(scanner.COMMA) {
+					break
+				}
+			}
+		}
+		var paren scanner.Token = p.peek()
+		p.consume(scanner.RIGHT_PAREN, "expected ')' after arguments.")
+
+		expr = &ast.Call{
+			Span:      expr.Location().To(paren.Span),
+			Callee:    expr,
+			Paren:     paren,
+			Arguments: arguments,
+		}
+	}
+	return expr
+}
+
+/*
+primary -> NUMBER | STRING | interpolation | IDENTIFIER | "true" | "false" | "empty" | "this" | "parent" "." IDENTIFIER | "(" expression ")" | list | map;
+list -> "[" (expression ("," expression)*)? "]";
+map -> "{" (expression ":" expression ("," expression ":" expression)*)? "}";
+*/
+func (p *Parser) primary() ast.Expression {
+	if p.match(scanner.NUMBER, scanner.STRING) {
+		return &ast.Literal{
+			Span:  p.previous().Span,
+			Value: p.previous().Literal,
+		}
+	}
+
+	if p.match(scanner.INTERPOLATION) {
+		return p.interpolation()
+	}
+
+	p.peekName()
+	if p.match(scanner.IDENTIFIER) {
+		return &ast.Variable{
+			Span: p.previous().Span,
+			Name: p.previous(),
+		}
+	}
+
+	if p.match(scanner.TRUE) {
+		return &ast.Literal{
+			Span:  p.previous().Span,
+			Value: true,
+		}
+	}
+
+	if p.match(scanner.FALSE) {
+		return &ast.Literal{
+			Span:  p.previous().Span,
+			Value: false,
+		}
+	}
+
+	if p.match(scanner.EMPTY) {
+		return &ast.Literal{
+			Span:  p.previous().Span,
+			Value: nil,
+		}
+	}
+
+	if p.match(scanner.THIS) {
+		if p.currentClass == NO_CLASS {
+			p.error(p.previous(), "cannot use 'this' outside of a class.")
+		}
+		return &ast.This{
+			Span:    p.previous().Span,
+			Keyword: p.previous(),
+		}
+	}
+
+	if p.match(scanner.PARENT) {
+		var keyword scanner.Token = p.previous()
+		if p.currentClass != IN_SUBCLASS {
+			p.error(keyword, "cannot use 'parent' in a class without a parent class.")
+		}
+		p.consume(scanner.DOT, "expected '.' after 'parent'.")
+		var method scanner.Token = p.peekName()
+		p.consume(scanner.IDENTIFIER, "expected parent method name.")
+		return &ast.Parent{
+			Span:    p.spanFrom(keyword),
+			Keyword: keyword,
+			Method:  method,
+		}
+	}
+
+	if p.match(scanner.LEFT_BRACKET) {
+		var bracket scanner.Token = p.previous()
+		var elements []ast.Expression = make([]ast.Expression, 0)
+		if p.peek().Type != scanner.RIGHT_BRACKET {
+			for {
+				elements = append(elements, p.expression())
+				if !p.match(scanner.COMMA) {
+					break
+				}
+			}
+		}
+		p.consume(scanner.RIGHT_BRACKET, "expected ']' after list elements.")
+		return &ast.ListLiteral{
+			Span:     p.spanFrom(bracket),
+			Elements: elements,
+		}
+	}
+
+	if p.match(scanner.LEFT_BRACE) {
+		var brace scanner.Token = p.previous()
+		var keys []ast.Expression = make([]ast.Expression, 0)
+		var values []ast.Expression = make([]ast.Expression, 0)
+		// braces do not stop line breaks, so entries may be spread over several lines
+		p.skipNewlines()
+		if p.peek().Type != scanner.RIGHT_BRACE {
+			for {
+				keys = append(keys, p.expression())
+				p.consume(scanner.COLON, "expected ':' after map key.")
+				values = append(values, p.expression())
+				p.skipNewlines()
+				if !p.match(scanner.COMMA) {
+					break
+				}
+				p.skipNewlines()
+			}
+		}
+		p.consume(scanner.RIGHT_BRACE, "expected '}' after map entries.")
+		return &ast.MapLiteral{
+			Span:   p.spanFrom(brace),
+			Brace:  brace,
+			Keys:   keys,
+			Values: values,
+		}
+	}
+
+	// "(" expression ")"
+	if p.match(scanner.LEFT_PAREN) {
+		var paren scanner.Token = p.previous()
+		var expr ast.Expression = p.expression()
+		if p.peek().Type != scanner.RIGHT_PAREN {
+			// FIX: throw error here, not return literal
+			// ERROR: Expect closing brackets for grouping!
+			p.error(p.peek(), "expected closing parantheses after statement.")
+		} else {
+			p.next()
+			return &ast.Group{
+				Span:       p.spanFrom(paren),
+				Expression: expr,
+			}
+		}
+	}
+
+	p.error(p.peek(), "unidentified expression.")
+	return nil
+}
+
+/*
+interpolation -> (INTERPOLATION expression (":" STRING)? INTERPOLATION_END)+ STRING;
+*/
+func (p *Parser) interpolation() ast.Expression {
+	var start scanner.Token = p.previous()
+	var expr ast.Interpolation = ast.Interpolation{}
+	for {
+		expr.Texts = append(expr.Texts, p.previous().Lexeme)
+		expr.Expressions = append(expr.Expressions, p.expression())
+
+		var format *scanner.Token
+		if p.match(scanner.COLON) {
+			var spec scanner.Token = p.peek()
+			p.consume(scanner.STRING, "expected a format specifier after ':'.")
+			format = &spec
+		}
+		expr.Formats = append(expr.Formats, format)
+		p.consume(scanner.INTERPOLATION_END, "expected '}' after interpolated expression.")
+
+		if !p.match(scanner.INTERPOLATION) {
+			break
+		}
+	}
+	p.consume(scanner.STRING, "expected the rest of the string after interpolated expression.")
+	expr.Texts = append(expr.Texts, p.previous().Lexeme)
+	expr.Span = p.spanFrom(start)
+	return &expr
+}
+
+/*
+*	Low level functions here
+ */
+func (p *Parser) match(tokenTypes ...scanner.TokenType) bool {
+	for _, tokenType := range tokenTypes {
+		if tokenType == p.peek().Type {
+			p.next()
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Parser) next() scanner.Token {
+	var c scanner.Token = p.tokens[p.current]
+	if !p.end() {
+		p.current += 1
+	}
+	return c
+}
+
+func (p *Parser) peek() scanner.Token {
+	return p.tokens[p.current]
+}
+
+/*
+peekName returns the next token where a name is expected, turning a soft keyword into an identifier.
+*/
+func (p *Parser) peekName() scanner.Token {
+	if scanner.IsSoftKeyword(p.peek().Type) {
+		p.tokens[p.current].Type = scanner.IDENTIFIER
+	}
+	return p.peek()
+}
+
+/*
+matchKeyword matches a soft keyword only when the token after it is one of the given types, where IDENTIFIER
+stands for any name. Anywhere else the soft keyword is left to be read as a name.
+*/
+func (p *Parser) matchKeyword(keyword scanner.TokenType, followers ...scanner.TokenType) bool {
+	if p.peek().Type != keyword {
+		return false
+	}
+	var after scanner.TokenType = p.peekNext().Type
+	for _, follower := range followers {
+		if after == follower || (follower == scanner.IDENTIFIER && scanner.IsSoftKeyword(after)) {
+			p.next()
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Parser) peekNext() scanner.Token {
+	if p.end() {
+		return p.tokens[p.current]
+	}
+	return p.tokens[p.current+1]
+}
+
+/*
+matchIndent consumes the start of an indented block, which is a line break followed by an INDENT.
+*/
+func (p *Parser) matchIndent() bool {
+	if p.peek().Type == scanner.NEWLINE && p.peekNext().Type == scanner.INDENT {
+		p.next()
+		p.next()
+		return true
+	}
+	return false
+}
+
+/*
+skipNewlineBefore lets a clause such as else continue a statement whose previous branch was a single line.
+*/
+func (p *Parser) skipNewlineBefore(tokenType scanner.TokenType) {
+	if p.peek().Type == scanner.NEWLINE && p.peekNext().Type == tokenType {
+		p.next()
+	}
+}
+
+func (p *Parser) skipNewlines() {
+	for p.match(scanner.NEWLINE) {
+	}
+}
+
+/*
+consume expects the next token to be of the given type, suggesting the keyword when it looks misspelled.
+*/
+func (p *Parser) consume(tokenType scanner.TokenType, message string) {
+	if p.peek().Type == tokenType {
+		p.next()
+		return
+	}
+	var d diagnostics.Diagnostic = scanner.NewDiagnostic(scanner.SYNTAX_ERROR, p.peek().Span, p.peek().Lexeme, message)
+	if keyword := scanner.KeywordOf(tokenType); keyword != "" && p.peek().Type == scanner.IDENTIFIER {
+		d.Hints = diagnostics.DidYouMean(p.peek().Lexeme, []string{keyword})
+	}
+	panic(d)
+}
+
+/*
+error raises a syntax error at a token.
+*/
+func (p *Parser) error(token scanner.Token, message string) {
+	panic(scanner.NewDiagnostic(scanner.SYNTAX_ERROR, token.Span, token.Lexeme, message))
+}
+
+func (p *Parser) synchronize() {
+	// defer func() {
+	// 	fmt.Printf("Synchronized at Line %d: %s\n", p.peek().line, p.peek().lexeme)
+	// }()
+	// consume the token that caused the error
+	// p.next()
+
+	// blocks and maps opened within the broken statement are skipped as a whole
+	var depth int = 0
+	for !p.end() {
+		switch p.peek().Type {
+		case scanner.LEFT_BRACE, scanner.INDENT:
+			depth += 1
+		case scanner.RIGHT_BRACE, scanner.DEDENT:
+			// the end of the enclosing block is left for the block to consume
+			if depth == 0 && len(p.constants) > 1 {
+				return
+			}
+			if depth > 0 {
+				depth -= 1
+			}
+		}
+		if depth > 0 {
+			p.next()
+			continue
+		}
+
+		if p.match(scanner.SEMICOLON, scanner.NEWLINE) {
+			/*
+				case when synchronizing points to right brace where it checked semicolon exists in previous():
+				{
+					...
+					set a to 1;
+				}
+			*/
+			// if p.peek().tokenType == RIGHT_BRACE {
+			// 	p.next()
+			// }
+			return
+		}
+
+		// tokens which usually mark the start of a statement
+		// set starting pointer to point to the start of a statement
+		tokenType := p.peek().Type
+		if tokenType == scanner.CLASS || tokenType == scanner.PROCEDURE || tokenType == scanner.ALGORITHM || tokenType == scanner.SET || tokenType == scanner.ASSUME || tokenType == scanner.USE ||
+			tokenType == scanner.FOR || tokenType == scanner.IF || tokenType == scanner.SAY ||
+			tokenType == scanner.WHILE || tokenType == scanner.RETURN {
+			return
+		}
+		p.next()
+	}
+}
+
+/*
+checkNotConstant reports reassignments of a constant declared earlier in the same block.
+*/
+func (p *Parser) checkNotConstant(name scanner.Token) {
+	if p.constants[len(p.constants)-1][name.Lexeme] {
+		p.error(name, "cannot reassign a constant declared with 'assume'.")
+	}
+}
+
+/*
+spanFrom returns the span from the start of the given token up to the end of the last token which was consumed,
+leaving out layout tokens since they cover no text of their own.
+*/
+func (p *Parser) spanFrom(start scanner.Token) scanner.Span {
+	var last int = p.current - 1
+	for last > 0 && (p.tokens[last].Type == scanner.NEWLINE || p.tokens[last].Type == scanner.INDENT || p.tokens[last].Type == scanner.DEDENT) {
+		last -= 1
+	}
+	if last < 0 {
+		return start.Span
+	}
+	return start.Span.To(p.tokens[last].Span)
+}
+
+func (p *Parser) previous() scanner.Token {
+	if p.current <= 0 {
+		return p.tokens[0]
+	}
+	return p.tokens[p.current-1]
+}
+
+func (p *Parser) end() bool {
+	return p.tokens[p.current].Type == scanner.EOF
+}
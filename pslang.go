@@ -0,0 +1,46 @@
+/*
+Package pslang runs programs written in the PSU language from Go. The language is implemented by the packages
+scanner, parser and interp, which turn source code into tokens, tokens into the syntax tree of package ast,
+and run the syntax tree:
+
+	var itpr *pslang.Interpreter = pslang.NewInterpreter()
+	if err := itpr.Run(`say "hello";`); err != nil {
+		fmt.Println(err)
+	}
+*/
+package pslang
+
+import (
+	"github.com/idea456/psu-lang/interp"
+	"github.com/idea456/psu-lang/scanner"
+)
+
+type Interpreter = interp.Interpreter
+
+/*
+SyntaxError is returned when source code cannot be scanned or parsed, and RuntimeError when a program
+stops on an error which it did not catch.
+*/
+type SyntaxError = scanner.SyntaxError
+
+type RuntimeError = interp.RuntimeError
+
+/*
+Value is a value handed between Go and a program, and NativeFunc a Go function which programs can call.
+*/
+type Value = interp.Value
+
+type NativeFunc = interp.NativeFunc
+
+const VARIADIC = interp.VARIADIC
+
+func NewInterpreter() *Interpreter {
+	return interp.NewInterpreter()
+}
+
+/*
+Run runs source code on a new interpreter.
+*/
+func Run(src string) error {
+	return interp.Run(src)
+}
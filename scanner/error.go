@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	diagnostics "github.com/idea456/psu-lang/error"
+)
+
+/*
+Kind of the errors found while scanning and parsing.
+*/
+const SYNTAX_ERROR = "SyntaxError"
+
+/*
+SyntaxError is returned when a file cannot be scanned or parsed, and holds every syntax error found in it.
+*/
+type SyntaxError struct {
+	Path   string
+	Errors []diagnostics.Diagnostic
+	source *Source
+}
+
+func NewSyntaxError(source *Source, errors []diagnostics.Diagnostic) *SyntaxError {
+	if source == nil {
+		source = &Source{}
+	}
+	var err SyntaxError = SyntaxError{}
+	err.Path = source.Path
+	err.Errors = errors
+	err.source = source
+	return &err
+}
+
+func (err *SyntaxError) Error() string {
+	var lines []string = make([]string, 0)
+	for _, d := range err.Errors {
+		lines = append(lines, fmt.Sprintf("%s:%d:%d: %s: %s", diagnostics.DisplayPath(err.Path), d.Span.Start.Line, d.Span.Start.Column, d.Kind, d.Message))
+	}
+	return strings.Join(lines, "\n")
+}
+
+/*
+Render shows every syntax error with the line it points at, coloured with ANSI escape codes when asked to.
+*/
+func (err *SyntaxError) Render(colour bool) string {
+	var renderer *diagnostics.Renderer = diagnostics.NewRenderer(err.source.Path, err.source.Text, colour)
+	var text string = ""
+	for _, d := range err.Errors {
+		text += renderer.Render(d)
+	}
+	return text
+}
+
+/*
+NewDiagnostic describes an error raised for a span of source code. The lexeme is named in front of the
+message, since it may differ from the source text underlined, e.g. a map key which was computed.
+*/
+func NewDiagnostic(kind string, span Span, lexeme string, message string) diagnostics.Diagnostic {
+	if lexeme != "" {
+		kind += fmt.Sprintf(" at '%s'", lexeme)
+	}
+	return diagnostics.Diagnostic{
+		Kind:    kind,
+		Message: message,
+		Span: diagnostics.Span{
+			Start: diagnostics.Position{Line: span.Start.Line, Column: span.Start.Column, Offset: span.Start.Offset},
+			End:   diagnostics.Position{Line: span.End.Line, Column: span.End.Column, Offset: span.End.Offset},
+		},
+	}
+}
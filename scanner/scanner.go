@@ -0,0 +1,751 @@
+/*
+Package scanner turns source code into tokens, each carrying the span of source code it was read from.
+Syntax errors found while scanning or parsing are returned as a *SyntaxError.
+*/
+package scanner
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	diagnostics "github.com/idea456/psu-lang/error"
+)
+
+type Scanner struct {
+	// source is scanned character by character rather than byte by byte
+	source  []rune
+	tokens  []Token
+	current int
+	line    int
+	// column and byte offset of the next character, and where the token being scanned starts
+	column int
+	offset int
+	start  Position
+	// when enabled, newlines terminate statements and indentation opens and closes blocks
+	indentation bool
+	indents     []int
+	// depth of parantheses and brackets, within which line breaks are ignored
+	parenDepth int
+	// braces which are open, holding MAP_BRACE for maps and otherwise the position in indents where the
+	// indentation of the block starts, which is taken from its first line once that line is reached
+	braces     []int
+	blockStart bool
+	// doc comments waiting for the token which follows them, by the position of that token
+	docs []scannedDoc
+	// file being scanned, which every span points back to
+	file *Source
+}
+
+/*
+Scanner constructor
+*/
+func NewScanner(text string) *Scanner {
+	scanner := Scanner{}
+	scanner.current = 0
+	scanner.line = 1
+	scanner.column = 1
+	scanner.offset = 0
+	scanner.source = []rune(text)
+	scanner.tokens = make([]Token, 0)
+	scanner.indentation = false
+	scanner.indents = []int{0}
+	scanner.file = &Source{Path: "", Text: text}
+	return &scanner
+}
+
+/*
+SetPath sets the file the source was read from, which is shown by errors. An empty path stands for the prompt.
+*/
+func (s *Scanner) SetPath(path string) {
+	s.file.Path = path
+}
+
+/*
+SetIndentation switches between brace-delimited blocks with semicolons and
+indentation-delimited blocks with newline-terminated statements.
+*/
+func (s *Scanner) SetIndentation(enabled bool) {
+	s.indentation = enabled
+}
+
+/*
+Scan turns the whole source into tokens ending with EOF. Scanning stops at the first syntax error,
+which is returned as a *SyntaxError.
+*/
+func (s *Scanner) Scan() (tokens []Token, err error) {
+	defer func() {
+		// a malformed layout cannot be parsed, so nothing is handed over to the parser
+		if r := recover(); r != nil {
+			d, ok := r.(diagnostics.Diagnostic)
+			if !ok {
+				panic(r)
+			}
+			tokens = []Token{{Type: EOF, Line: s.line}}
+			err = NewSyntaxError(s.file, []diagnostics.Diagnostic{d})
+		}
+	}()
+
+	s.start = s.position()
+	if s.indentation {
+		s.scanIndentation()
+	}
+	for !s.end() {
+		s.scanToken()
+	}
+	s.start = s.position()
+	if s.indentation {
+		// close the last line and every block which is still open
+		s.addNewline()
+		for len(s.indents) > 1 {
+			s.indents = s.indents[:len(s.indents)-1]
+			s.addLayout(DEDENT)
+		}
+	}
+	// append end
+	s.addToken(EOF, "", nil)
+	s.attachDocs()
+	return s.tokens, nil
+}
+
+func (s *Scanner) scanToken() {
+	s.start = s.position()
+	if s.scanComment() {
+		return
+	}
+
+	var c string = s.peek()
+	switch {
+	case s.match(">", "<", "!", "="):
+		if s.match("=") {
+			s.addToken(keywords[c+"="], c+"=", c+"=")
+		} else if c != "=" {
+			s.addToken(keywords[c], c, c)
+		} else {
+			s.error(s.start, c, "unexpected character, use '==' to compare or 'to' to assign.")
+		}
+
+	// ".." has to be told apart from a single "."
+	case s.lookahead(".."):
+		s.next()
+		s.next()
+		s.addToken(DOT_DOT, "..", nil)
+
+	// scan for operators, brackets and semicolon
+	case s.match("+", "-", "*", "/", "%", "(", ")", "{", "}", "[", "]", ";", ",", ":", "."):
+		// nesting is tracked first, so that blocks closed by a brace are ended before the brace
+		s.trackNesting(c)
+		s.addToken(keywords[c], c, nil)
+
+	// ignore whitespaces, tabs and newlines
+	case s.match("\n"):
+		if s.indentation {
+			s.addNewline()
+			s.scanIndentation()
+		}
+	case s.match("\r", " ", "\t"):
+
+	case s.match("\""):
+		s.scanString(false)
+
+	// raw strings are prefixed with r and keep backslashes and braces as they are written
+	case c == "r" && s.peekNext() == "\"":
+		s.next()
+		s.next()
+		s.scanString(true)
+
+	// scan for numbers
+	case s.isNumber(c):
+		s.scanNumber()
+
+	// scan for keywords or variable names
+	case s.isAlpha(c):
+		s.scanIdentifier()
+
+	default:
+		s.error(s.start, c, "unexpected character.")
+	}
+}
+
+/*
+addToken appends a token which spans from the start of the current token up to the current position.
+*/
+func (s *Scanner) addToken(tokenType TokenType, lexeme string, literal interface{}) {
+	s.tokens = append(s.tokens, Token{
+		Type:    tokenType,
+		Lexeme:  lexeme,
+		Literal: literal,
+		Line:    s.start.Line,
+		Span:    Span{Start: s.start, End: s.position(), Source: s.file},
+	})
+}
+
+/*
+MAP_BRACE marks braces opening a map, within which lines may be indented in any way.
+*/
+const MAP_BRACE = -1
+
+/*
+scanIndentation measures the leading whitespace of a line, where a tab counts as 4 spaces,
+and emits an INDENT or DEDENT tokens when it differs from the enclosing block.
+Blank lines and lines within brackets or maps never change the indentation. Blocks in braces
+are indented relative to their first line, and are only closed by their closing brace.
+*/
+func (s *Scanner) scanIndentation() {
+	var width int = 0
+	for !s.end() && (s.peek() == " " || s.peek() == "\t") {
+		if s.next() == "\t" {
+			width += 4
+		} else {
+			width += 1
+		}
+	}
+
+	// lines holding nothing but a comment count as blank lines
+	if s.lookahead("/*") {
+		s.scanComment()
+		for !s.end() && (s.peek() == " " || s.peek() == "\t") {
+			s.next()
+		}
+	}
+	if s.lookahead("//") || s.lookahead("#") {
+		return
+	}
+	if s.end() || s.peek() == "\n" || s.peek() == "\r" || s.parenDepth > 0 || s.inMap() {
+		return
+	}
+
+	// layout tokens are placed at the first character of the line
+	s.start = s.position()
+	// a closing brace ends the blocks indented within its braces however it is indented itself
+	if len(s.braces) > 0 && s.peek() == "}" {
+		return
+	}
+	if s.blockStart {
+		s.blockStart = false
+		s.indents = append(s.indents, width)
+		return
+	}
+	var top int = s.indents[len(s.indents)-1]
+	if width > top {
+		s.indents = append(s.indents, width)
+		s.addLayout(INDENT)
+		return
+	}
+	// lines within a block in braces cannot close it, which is left to its closing brace
+	var floor int = 0
+	if len(s.braces) > 0 {
+		floor = s.braces[len(s.braces)-1]
+	}
+	for width < s.indents[len(s.indents)-1] && len(s.indents)-1 > floor {
+		s.indents = s.indents[:len(s.indents)-1]
+		s.addLayout(DEDENT)
+	}
+	if width != s.indents[len(s.indents)-1] {
+		s.error(s.start, "", "indentation does not match any enclosing block.")
+	}
+}
+
+func (s *Scanner) inMap() bool {
+	return len(s.braces) > 0 && s.braces[len(s.braces)-1] == MAP_BRACE
+}
+
+/*
+opensBlock tells a brace opening a block, which follows the header of a statement or another statement,
+from a brace opening a map, which follows an operator or the start of an expression.
+*/
+func (s *Scanner) opensBlock() bool {
+	if len(s.tokens) == 0 {
+		return true
+	}
+	switch s.tokens[len(s.tokens)-1].Type {
+	case RIGHT_PAREN, IDENTIFIER, THEN, DO, ELSE, TRY, CATCH, FINALLY, LEFT_BRACE, RIGHT_BRACE, SEMICOLON, NEWLINE, INDENT, DEDENT:
+		return true
+	}
+	return false
+}
+
+/*
+addNewline terminates the current line, collapsing blank lines and ignoring line breaks within brackets.
+*/
+func (s *Scanner) addNewline() {
+	if s.parenDepth > 0 || len(s.tokens) == 0 || s.tokens[len(s.tokens)-1].Type == NEWLINE {
+		return
+	}
+	s.addLayout(NEWLINE)
+}
+
+/*
+addLayout appends a NEWLINE, INDENT or DEDENT token, which covers no source text at the start of the current token.
+*/
+func (s *Scanner) addLayout(tokenType TokenType) {
+	s.tokens = append(s.tokens, Token{
+		Type: tokenType,
+		Line: s.start.Line,
+		Span: Span{Start: s.start, End: s.start, Source: s.file},
+	})
+}
+
+func (s *Scanner) trackNesting(c string) {
+	switch c {
+	case "(", "[":
+		s.parenDepth += 1
+	case ")", "]":
+		if s.parenDepth > 0 {
+			s.parenDepth -= 1
+		}
+	case "{":
+		if !s.indentation || !s.opensBlock() {
+			s.braces = append(s.braces, MAP_BRACE)
+			return
+		}
+		s.braces = append(s.braces, len(s.indents))
+		s.blockStart = true
+	case "}":
+		if len(s.braces) == 0 {
+			return
+		}
+		var start int = s.braces[len(s.braces)-1]
+		s.braces = s.braces[:len(s.braces)-1]
+		s.blockStart = false
+		if start == MAP_BRACE {
+			return
+		}
+		// blocks indented within the braces end with them
+		for len(s.indents)-1 > start {
+			s.indents = s.indents[:len(s.indents)-1]
+			s.addLayout(DEDENT)
+		}
+		if len(s.indents) > start {
+			s.indents = s.indents[:start]
+		}
+	}
+}
+
+/*
+scanComment skips over a comment, reporting whether there was one. Line comments start with "//" or "#",
+and block comments run from "/*" to the matching star and slash, which lets them be nested. Doc comments, written with "///" or "/**",
+are kept for the token which follows them.
+*/
+func (s *Scanner) scanComment() bool {
+	if s.lookahead("//") || s.lookahead("#") {
+		var doc bool = s.lookahead("///") && !s.lookahead("////")
+		var text string = ""
+		for !s.end() && s.peek() != "\n" {
+			text += s.next()
+		}
+		if doc {
+			s.addDoc(strings.TrimSpace(strings.TrimPrefix(text, "///")))
+		}
+		return true
+	}
+
+	if !s.lookahead("/*") {
+		return false
+	}
+	var begin Position = s.position()
+	var doc bool = s.lookahead("/**") && !s.lookahead("/**/")
+	s.next()
+	s.next()
+	var text string = ""
+	for depth := 1; depth > 0; {
+		switch {
+		case s.end():
+			s.error(begin, "/*", "unterminated block comment.")
+		case s.lookahead("/*"):
+			depth += 1
+			text += s.next() + s.next()
+		case s.lookahead("*/"):
+			depth -= 1
+			if depth > 0 {
+				text += s.next() + s.next()
+			} else {
+				s.next()
+				s.next()
+			}
+		default:
+			text += s.next()
+		}
+	}
+
+	if doc {
+		// leading stars of every line are only decoration
+		var lines []string = strings.Split(strings.TrimPrefix(text, "*"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		}
+		s.addDoc(strings.TrimSpace(strings.Join(lines, "\n")))
+	}
+	return true
+}
+
+/*
+scannedDoc is a doc comment together with the position of the token it was written in front of.
+*/
+type scannedDoc struct {
+	position int
+	text     string
+}
+
+/*
+addDoc keeps a doc comment for the next token, joining consecutive doc comments into one.
+*/
+func (s *Scanner) addDoc(text string) {
+	if last := len(s.docs) - 1; last >= 0 && s.docs[last].position == len(s.tokens) {
+		s.docs[last].text += "\n" + text
+		return
+	}
+	s.docs = append(s.docs, scannedDoc{position: len(s.tokens), text: text})
+}
+
+/*
+attachDocs hands every doc comment to the first token after it which is not part of the layout.
+*/
+func (s *Scanner) attachDocs() {
+	for _, doc := range s.docs {
+		var position int = doc.position
+		for s.tokens[position].Type == NEWLINE || s.tokens[position].Type == INDENT || s.tokens[position].Type == DEDENT {
+			position += 1
+		}
+		s.tokens[position].Doc = doc.text
+	}
+}
+
+func (s *Scanner) scanNumber() {
+	var numStr string = ""
+	for s.peek() >= "0" && s.peek() <= "9" && !s.end() {
+		numStr += s.next()
+		// consume decimal point also, unless it starts a range such as 1..n
+		if s.peek() == "." && s.isNumber(s.peekNext()) {
+			numStr += s.next()
+		}
+	}
+
+	// numbers with a decimal point are exact fractions, all other numbers are integers
+	var num interface{}
+	if strings.Contains(numStr, ".") {
+		num, _ = new(big.Rat).SetString(numStr)
+	} else if integer, err := strconv.Atoi(numStr); err == nil {
+		num = integer
+	} else {
+		num, _ = new(big.Int).SetString(numStr, 10)
+	}
+
+	s.addToken(NUMBER, numStr, num)
+}
+
+/*
+scanString reads a string literal after its opening quote. Strings end on the line they start on,
+except for triple-quoted strings which may span several lines. Backslashes start escape sequences, and
+expressions written between "{" and "}" are interpolated, so such a string is handed over as an INTERPOLATION
+token with the text before each expression followed by the tokens of the expression itself and an INTERPOLATION_END,
+and ends with a STRING token holding the remaining text. "{{" and "}}" stand for braces. Raw strings keep every character as written.
+*/
+func (s *Scanner) scanString(raw bool) {
+	// the rest of a string starts after each interpolated expression, so errors point at the opening quote instead
+	var begin Position = s.start
+	var triple bool = s.lookahead("\"\"")
+	if triple {
+		s.next()
+		s.next()
+	}
+
+	var value string = ""
+	for !s.closeString(triple) {
+		if s.end() {
+			s.error(begin, "\"", "unterminated string.")
+		}
+		var c string = s.next()
+		switch {
+		case c == "\n":
+			if !triple {
+				s.error(begin, "\"", "unterminated string, use triple quotes for strings over several lines.")
+			}
+			value += c
+		case raw:
+			value += c
+		case c == "\\":
+			value += s.scanEscape()
+		case (c == "{" || c == "}") && s.peek() == c:
+			s.next()
+			value += c
+		case c == "{":
+			s.addToken(INTERPOLATION, value, value)
+			s.scanInterpolation(triple)
+			value = ""
+		default:
+			value += c
+		}
+	}
+
+	s.addToken(STRING, value, value)
+}
+
+/*
+closeString consumes the closing quotes of a string, reporting whether the string ends here.
+*/
+func (s *Scanner) closeString(triple bool) bool {
+	if triple && s.lookahead("\"\"\"") {
+		s.next()
+		s.next()
+		s.next()
+		return true
+	}
+	return !triple && s.match("\"")
+}
+
+/*
+scanEscape reads the escape sequence following a backslash and returns the text it stands for.
+*/
+func (s *Scanner) scanEscape() string {
+	// the backslash in front has already been consumed
+	var begin Position = Position{Line: s.line, Column: s.column - 1, Offset: s.offset - 1}
+	if s.end() {
+		s.error(begin, "\\", "unterminated string.")
+	}
+	var c string = s.next()
+	switch c {
+	case "n":
+		return "\n"
+	case "t":
+		return "\t"
+	case "r":
+		return "\r"
+	case "0":
+		return "\x00"
+	case "\"", "\\", "{", "}":
+		return c
+	case "u":
+		// unicode escapes name a code point in hexadecimal, e.g. \u{1F600}
+		if !s.match("{") {
+			s.error(begin, "\\u", "expected '{' after '\\u'.")
+		}
+		var hex string = ""
+		for !s.end() && s.peek() != "}" && s.peek() != "\"" && s.peek() != "\n" {
+			hex += s.next()
+		}
+		if !s.match("}") {
+			s.error(begin, "\\u{"+hex, "expected '}' after unicode escape.")
+		}
+		code, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) > 6 || !utf8.ValidRune(rune(code)) {
+			s.error(begin, "\\u{"+hex+"}", "invalid unicode code point.")
+		}
+		return string(rune(code))
+	}
+	s.error(begin, "\\"+c, "invalid escape sequence.")
+	return ""
+}
+
+/*
+scanInterpolation reads an interpolated expression up to its closing "}" and scans it into tokens.
+An optional format specifier after a top-level ":" is handed over as a COLON followed by a STRING.
+*/
+func (s *Scanner) scanInterpolation(multiline bool) {
+	var begin Position = s.position()
+	var expression string = ""
+	var depth int = 0
+	for !s.end() && !(depth == 0 && (s.peek() == "}" || s.peek() == ":")) {
+		var c string = s.next()
+		switch c {
+		case "\n":
+			if !multiline {
+				s.error(begin, "{", "unterminated interpolation in string.")
+			}
+		case "(", "[", "{":
+			depth += 1
+		case ")", "]", "}":
+			depth -= 1
+		case "\"":
+			// nested string literals are copied whole, so their braces and colons are left alone
+			for !s.end() && s.peek() != "\"" && s.peek() != "\n" {
+				if s.peek() == "\\" {
+					c += s.next()
+				}
+				c += s.next()
+			}
+			if !s.end() {
+				c += s.next()
+			}
+		}
+		expression += c
+	}
+
+	var colon Position = s.position()
+	var hasFormat bool = s.match(":")
+	var spec Position = s.position()
+	var format string = ""
+	for hasFormat && !s.end() && s.peek() != "}" && s.peek() != "\"" && s.peek() != "\n" {
+		format += s.next()
+	}
+	if s.end() || s.peek() != "}" {
+		s.error(begin, "{", "unterminated interpolation in string.")
+	}
+	if strings.TrimSpace(expression) == "" {
+		s.error(begin, "{", "expected an expression inside the braces.")
+	}
+
+	// the expression is scanned on its own from where it starts, and its tokens are spliced into the string
+	var inner *Scanner = NewScanner(expression)
+	inner.line, inner.column, inner.offset = begin.Line, begin.Column, begin.Offset
+	inner.file = s.file
+	for !inner.end() {
+		inner.scanToken()
+	}
+	s.tokens = append(s.tokens, inner.tokens...)
+	if hasFormat {
+		s.tokens = append(s.tokens, Token{
+			Type:   COLON,
+			Lexeme: ":",
+			Line:   colon.Line,
+			Span:   Span{Start: colon, End: spec, Source: s.file},
+		}, Token{
+			Type:    STRING,
+			Lexeme:  format,
+			Literal: format,
+			Line:    spec.Line,
+			Span:    Span{Start: spec, End: s.position(), Source: s.file},
+		})
+	}
+
+	s.start = s.position()
+	s.next()
+	s.addToken(INTERPOLATION_END, "}", nil)
+	// the rest of the string starts after the closing brace
+	s.start = s.position()
+}
+
+func (s *Scanner) scanIdentifier() {
+	var name string = ""
+	// case when e.g. or vs order, perform maximal munching
+	for s.isIdentifierPart(s.peek()) && !s.end() {
+		name += s.next()
+	}
+
+	keyword, exist := keywords[name]
+	if !exist {
+		s.addToken(IDENTIFIER, name, name)
+	} else {
+		s.addToken(keyword, name, name)
+	}
+
+}
+
+/*
+@return
+next() only mutates the current pointer and returns the current character being consumed
+*/
+func (s *Scanner) next() string {
+	var c rune = s.source[s.current]
+	s.current += 1
+	s.offset += utf8.RuneLen(c)
+	if c == '\n' {
+		s.line += 1
+		s.column = 1
+	} else {
+		s.column += 1
+	}
+	return string(c)
+}
+
+/*
+error raises a syntax error covering the source from a position up to the next character.
+*/
+func (s *Scanner) error(from Position, lexeme string, message string) {
+	panic(NewDiagnostic(SYNTAX_ERROR, Span{Start: from, End: s.position(), Source: s.file}, lexeme, message))
+}
+
+/*
+position returns the position of the next character.
+*/
+func (s *Scanner) position() Position {
+	return Position{Line: s.line, Column: s.column, Offset: s.offset}
+}
+
+func (s *Scanner) peek() string {
+	if s.end() {
+		return ""
+	}
+	return string(s.source[s.current])
+}
+
+func (s *Scanner) peekNext() string {
+	if s.current+1 >= len(s.source) {
+		return ""
+	}
+	return string(s.source[s.current+1])
+}
+
+func (s *Scanner) previous() string {
+	if s.current <= 0 {
+		return string(s.source[0])
+	}
+	return string(s.source[s.current-1])
+}
+
+/*
+lookahead reports whether the source continues with the given text.
+*/
+func (s *Scanner) lookahead(text string) bool {
+	var i int = s.current
+	for _, r := range text {
+		if i >= len(s.source) || s.source[i] != r {
+			return false
+		}
+		i += 1
+	}
+	return true
+}
+
+/*
+isAlpha accepts letters of any language, so identifiers can be written in the programmer's own language.
+*/
+func (s *Scanner) isAlpha(c string) bool {
+	r, _ := utf8.DecodeRuneInString(c)
+	return unicode.IsLetter(r)
+}
+
+/*
+isIdentifierPart also accepts the combining marks which several scripts write within words.
+*/
+func (s *Scanner) isIdentifierPart(c string) bool {
+	r, _ := utf8.DecodeRuneInString(c)
+	return s.isAlpha(c) || s.isNumber(c) || unicode.Is(unicode.M, r)
+}
+
+func (s *Scanner) isNumber(c string) bool {
+	return c >= "0" && c <= "9"
+}
+
+func (s *Scanner) end() bool {
+	return s.current >= len(s.source)
+}
+
+func (s *Scanner) match(keywords ...string) bool {
+	for _, kw := range keywords {
+		if s.peek() == kw {
+			s.next()
+			return true
+		}
+	}
+	return false
+}
+
+/*
+IsIdentifier reports whether a name would be scanned as an IDENTIFIER.
+*/
+func IsIdentifier(name string) bool {
+	var s *Scanner = NewScanner(name)
+	if s.end() || !s.isAlpha(s.next()) {
+		return false
+	}
+	for !s.end() {
+		if !s.isIdentifierPart(s.next()) {
+			return false
+		}
+	}
+	tokenType, isKeyword := keywords[name]
+	return !isKeyword || IsSoftKeyword(tokenType)
+}
@@ -0,0 +1,42 @@
+package scanner
+
+/*
+Position is a place in the source code. Lines and columns count from 1, where columns count characters,
+while offsets count bytes from the start of the source.
+*/
+type Position struct {
+	Line   int
+	Column int
+	Offset int
+}
+
+/*
+Span is the range of source code covered by a token or a syntax tree node, where end is just past its last character.
+Every expression and statement embeds the span it was parsed from.
+*/
+type Span struct {
+	Start Position
+	End   Position
+	// file the span was scanned from, which is nil for tokens made up by the interpreter
+	Source *Source
+}
+
+/*
+Source is a file handed to the scanner, kept so that errors can show the line they were raised on.
+An empty path stands for code typed into the prompt.
+*/
+type Source struct {
+	Path string
+	Text string
+}
+
+func (span Span) Location() Span {
+	return span
+}
+
+/*
+To returns the span from the start of this span up to the end of another one.
+*/
+func (span Span) To(other Span) Span {
+	return Span{Start: span.Start, End: other.End, Source: span.Source}
+}
@@ -0,0 +1,232 @@
+package scanner
+
+import "unicode"
+
+type TokenType int
+
+const (
+	IDENTIFIER TokenType = iota
+	STRING
+	// text in front of an interpolated expression within a string
+	INTERPOLATION
+	// closing brace of an interpolated expression, so that the expression cannot run on into the string
+	INTERPOLATION_END
+	NUMBER
+
+	AND
+	CLASS
+	EXTENDS
+	ELSE
+	FALSE
+	PROCEDURE
+	ALGORITHM
+	OUTPUTS
+	FOR
+	EACH
+	IN
+	FROM
+	IF
+	THEN
+	NIL
+	OR
+	SAY
+	RETURN
+	TRY
+	CATCH
+	FINALLY
+	RAISE
+	AS
+	PARENT
+	THIS
+	TRUE
+	WHILE
+	DO
+	BREAK
+	CONTINUE
+	SKIP
+	EXIT
+	ASSUME
+	SET
+	TO
+	USE
+	LENGTH
+	KEYS
+	OF
+	SEPARATED
+	HAS
+	DELETE
+
+	NOT           // !
+	NOT_EQUAL     // !=
+	EQUAL_EQUAL   // ==
+	LESS          // <
+	LESS_EQUAL    // <=
+	GREATER       // >
+	GREATER_EQUAL // >=
+
+	LEFT_PAREN
+	RIGHT_PAREN
+	LEFT_BRACE
+	RIGHT_BRACE
+	LEFT_BRACKET
+	RIGHT_BRACKET
+	COMMA
+	COLON
+	DOT
+	DOT_DOT
+	MINUS
+	PLUS
+	SEMICOLON
+	SLASH
+	STAR
+	MODULUS
+	DIV
+	INCREMENT
+	DECREMENT
+	BY
+
+	EMPTY
+	UNKNOWN
+
+	// layout tokens, only emitted when scanning with indentation
+	NEWLINE
+	INDENT
+	DEDENT
+
+	EOF
+)
+
+type Token struct {
+	Type    TokenType
+	Lexeme  string
+	Literal interface{}
+	Line    int
+	Span    Span
+	// doc comment written right in front of the token
+	Doc string
+}
+
+var keywords = map[string]TokenType{
+	"and":       AND,
+	"class":     CLASS,
+	"extends":   EXTENDS,
+	"else":      ELSE,
+	"false":     FALSE,
+	"procedure": PROCEDURE,
+	"algorithm": ALGORITHM,
+	"outputs":   OUTPUTS,
+	"for":       FOR,
+	"each":      EACH,
+	"in":        IN,
+	"from":      FROM,
+	"if":        IF,
+	"then":      THEN,
+	"or":        OR,
+	"say":       SAY,
+	"return":    RETURN,
+	"try":       TRY,
+	"catch":     CATCH,
+	"finally":   FINALLY,
+	"raise":     RAISE,
+	"as":        AS,
+	"parent":    PARENT, // TODO: can change to PARENT
+	"this":      THIS,
+	"true":      TRUE,
+	"while":     WHILE,
+	"do":        DO,
+	"break":     BREAK,
+	"continue":  CONTINUE,
+	"skip":      SKIP,
+	"exit":      EXIT,
+	"assume":    ASSUME,
+	"set":       SET,
+	"to":        TO,
+	"use":       USE,
+	"length":    LENGTH,
+	"keys":      KEYS,
+	"of":        OF,
+	"separated": SEPARATED,
+	"has":       HAS,
+	"delete":    DELETE,
+	"increment": INCREMENT,
+	"decrement": DECREMENT,
+	"by":        BY,
+	"!":         NOT,
+	"!=":        NOT_EQUAL,
+	"==":        EQUAL_EQUAL,
+	"<":         LESS,
+	"<=":        LESS_EQUAL,
+	">":         GREATER,
+	">=":        GREATER_EQUAL,
+	"(":         LEFT_PAREN,
+	")":         RIGHT_PAREN,
+	"{":         LEFT_BRACE,
+	"}":         RIGHT_BRACE,
+	"[":         LEFT_BRACKET,
+	"]":         RIGHT_BRACKET,
+	",":         COMMA,
+	":":         COLON,
+	".":         DOT,
+	"..":        DOT_DOT,
+	"-":         MINUS,
+	"+":         PLUS,
+	";":         SEMICOLON,
+	"/":         SLASH,
+	"*":         STAR,
+	"%":         MODULUS,
+	"div":       DIV,
+	"empty":     EMPTY,
+}
+
+/*
+softKeywords are only keywords where they begin or continue the construct they belong to, such as 'length'
+in "length of xs", and are names everywhere else so that programs which use them as variables keep working.
+*/
+var softKeywords = map[TokenType]bool{
+	LENGTH:    true,
+	OF:        true,
+	FROM:      true,
+	OUTPUTS:   true,
+	KEYS:      true,
+	HAS:       true,
+	DELETE:    true,
+	EACH:      true,
+	IN:        true,
+	SKIP:      true,
+	EXIT:      true,
+	AS:        true,
+	DIV:       true,
+	SEPARATED: true,
+}
+
+/*
+IsSoftKeyword reports whether a keyword may also be used as a name.
+*/
+func IsSoftKeyword(tokenType TokenType) bool {
+	return softKeywords[tokenType]
+}
+
+/*
+KeywordNames lists the keywords which are written as words rather than symbols.
+*/
+func KeywordNames() []string {
+	var names []string = make([]string, 0)
+	for name := range keywords {
+		if unicode.IsLetter(rune(name[0])) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+/*
+KeywordOf returns how a keyword token is written, or an empty string for other tokens.
+*/
+func KeywordOf(tokenType TokenType) string {
+	for _, name := range KeywordNames() {
+		if keywords[name] == tokenType {
+			return name
+		}
+	}
+	return ""
+}